@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// adfNode is the subset of the Atlassian Document Format we read and write.
+type adfNode struct {
+	Type    string    `json:"type"`
+	Version int       `json:"version,omitempty"`
+	Text    string    `json:"text,omitempty"`
+	Content []adfNode `json:"content,omitempty"`
+}
+
+// textToADF converts plain text to an ADF document: blank lines separate
+// paragraphs and single newlines become hard breaks.
+func textToADF(s string) adfNode {
+	doc := adfNode{Type: "doc", Version: 1}
+	for _, para := range strings.Split(strings.TrimSpace(s), "\n\n") {
+		para = strings.TrimSpace(para)
+		if para == "" {
+			continue
+		}
+		p := adfNode{Type: "paragraph"}
+		for i, line := range strings.Split(para, "\n") {
+			if i > 0 {
+				p.Content = append(p.Content, adfNode{Type: "hardBreak"})
+			}
+			if line != "" {
+				p.Content = append(p.Content, adfNode{Type: "text", Text: line})
+			}
+		}
+		doc.Content = append(doc.Content, p)
+	}
+	return doc
+}
+
+// adfToText flattens an ADF document to plain text, the inverse of
+// textToADF. Formatting and non-text nodes are dropped.
+func adfToText(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var doc adfNode
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return ""
+	}
+	var paras []string
+	var walk func(n adfNode, b *strings.Builder)
+	walk = func(n adfNode, b *strings.Builder) {
+		switch n.Type {
+		case "text":
+			b.WriteString(n.Text)
+		case "hardBreak":
+			b.WriteByte('\n')
+		}
+		for _, c := range n.Content {
+			walk(c, b)
+		}
+	}
+	for _, block := range doc.Content {
+		var b strings.Builder
+		walk(block, &b)
+		paras = append(paras, b.String())
+	}
+	return strings.Join(paras, "\n\n")
+}
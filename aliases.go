@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// statusAliases returns the config file's [aliases] section: short names
+// for transition target statuses.
+//
+//	[aliases]
+//	review = "In Review"
+func statusAliases(c fileConfig) map[string]string {
+	return c.Section("aliases")
+}
+
+// resolveStatusAlias returns the status an alias stands for, ignoring
+// case. Anything that isn't an alias is returned unchanged and matched
+// against the transitions as usual.
+func resolveStatusAlias(c fileConfig, status string) string {
+	for name, full := range statusAliases(c) {
+		if strings.EqualFold(name, status) && full != "" {
+			return full
+		}
+	}
+	return status
+}
+
+func aliasesCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("aliases")
+	if pos := parseArgs(fs, args); len(pos) > 0 {
+		return usageError("usage: jira-cli aliases")
+	}
+	aliases := statusAliases(cfg.File)
+	if len(aliases) == 0 {
+		fmt.Printf("No aliases. Add an [aliases] section to %s, e.g.\n\n[aliases]\nreview = \"In Review\"\n", orDash(configPath()))
+		return nil
+	}
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(w, "%s\t%s\n", name, aliases[name])
+	}
+	return w.Flush()
+}
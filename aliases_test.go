@@ -0,0 +1,50 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestResolveStatusAlias(t *testing.T) {
+	c, err := parseConfig(strings.NewReader("[aliases]\nreview = \"In Review\"\nwip = In Progress\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct{ in, want string }{
+		{"review", "In Review"},
+		{"REVIEW", "In Review"},
+		{"wip", "In Progress"},
+		// Not an alias: matched against the transitions as typed.
+		{"Done", "Done"},
+		{"in review", "in review"},
+	}
+	for _, tt := range tests {
+		if got := resolveStatusAlias(c, tt.in); got != tt.want {
+			t.Errorf("resolveStatusAlias(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+	if got := resolveStatusAlias(nil, "review"); got != "review" {
+		t.Errorf("without a config: %q", got)
+	}
+}
+
+func TestTransitionViaAlias(t *testing.T) {
+	f := newFakeJira(t)
+	f.replyTransitions("In Progress:indeterminate", "In Review:indeterminate")
+	cfg := f.config()
+	cfg.File = fileConfig{"aliases": {"review": "In Review"}}
+
+	var err error
+	out := captureStdout(t, func() { err = transitionCmd(cfg, []string{"IS-1", "review"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	posts := f.requests("POST", "/rest/api/3/issue/IS-1/transitions")
+	if len(posts) != 1 || posts[0].Body != `{"transition":{"id":"2"}}` {
+		t.Errorf("requests = %+v, want transition 2", posts)
+	}
+	if !slices.Contains(strings.Split(out, "\n"), `Transitioned IS-1 to "In Review"`) {
+		t.Errorf("output = %q", out)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// apiMethods are the methods api sends, and whether each only reads.
+// The others change data, so they need -force.
+var apiMethods = map[string]bool{
+	"GET":     true,
+	"HEAD":    true,
+	"OPTIONS": true,
+	"POST":    false,
+	"PUT":     false,
+	"PATCH":   false,
+	"DELETE":  false,
+}
+
+func apiCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("api")
+	force := fs.Bool("force", false, "allow POST, PUT, PATCH and DELETE requests")
+	pos := parseArgs(fs, args)
+	if len(pos) < 2 || len(pos) > 3 {
+		return usageError("usage: jira-cli api <METHOD> <path> [body-json|-]")
+	}
+
+	method := strings.ToUpper(pos[0])
+	safe, ok := apiMethods[method]
+	if !ok {
+		return usageError("unknown method %q (want GET, HEAD, OPTIONS, POST, PUT, PATCH or DELETE)", pos[0])
+	}
+	target, err := url.Parse(pos[1])
+	if err != nil || target.IsAbs() {
+		return usageError("path must be relative to JIRA_URL, e.g. /rest/api/3/myself")
+	}
+	if !safe && !*force {
+		return usageError("refusing to send %s without -force", method)
+	}
+
+	var body any
+	if len(pos) == 3 {
+		raw := []byte(pos[2])
+		if pos[2] == "-" {
+			b, err := io.ReadAll(stdin)
+			if err != nil {
+				return err
+			}
+			raw = b
+		}
+		if !json.Valid(raw) {
+			return usageError("request body is not valid JSON")
+		}
+		body = json.RawMessage(raw)
+	}
+
+	var out json.RawMessage
+	if err := doJSON(cfg, method, apiURL(cfg, target.Query(), target.EscapedPath()), body, &out); err != nil {
+		return err
+	}
+	if len(out) == 0 {
+		return nil
+	}
+
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, out, "", "  "); err != nil {
+		return err
+	}
+	fmt.Println(pretty.String())
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAPICmdForwardsRequest(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("POST /rest/api/3/issue/IS-1/comment", 201, map[string]any{"id": "10", "author": map[string]any{"name": "me"}})
+
+	var err error
+	out := captureStdout(t, func() {
+		err = apiCmd(f.config(), []string{"-force", "post", "/rest/api/3/issue/IS-1/comment?notify=false", `{"body": "hi"}`})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs := f.requests("POST", "/rest/api/3/issue/IS-1/comment")
+	if len(reqs) != 1 {
+		t.Fatalf("%d requests, want 1", len(reqs))
+	}
+	r := reqs[0]
+	if r.Query.Get("notify") != "false" {
+		t.Errorf("query = %v, want notify=false", r.Query)
+	}
+	if r.Body != `{"body":"hi"}` {
+		t.Errorf("body = %s", r.Body)
+	}
+	want := "{\n  \"author\": {\n    \"name\": \"me\"\n  },\n  \"id\": \"10\"\n}\n"
+	if out != want {
+		t.Errorf("output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestAPICmdRefusesWritesWithoutForce(t *testing.T) {
+	f := newFakeJira(t)
+	for _, method := range []string{"POST", "PUT", "patch", "DELETE"} {
+		err := apiCmd(f.config(), []string{method, "/rest/api/3/issue/IS-1", "{}"})
+		if !errors.Is(err, ErrUsage) || !strings.Contains(err.Error(), "without -force") {
+			t.Errorf("%s: err = %v, want a usage error", method, err)
+		}
+	}
+	if len(f.reqs) != 0 {
+		t.Errorf("sent %d requests", len(f.reqs))
+	}
+}
+
+func TestAPICmdRejectsUnknownMethod(t *testing.T) {
+	f := newFakeJira(t)
+	err := apiCmd(f.config(), []string{"-force", "FETCH", "/rest/api/3/myself"})
+	if !errors.Is(err, ErrUsage) || !strings.Contains(err.Error(), `unknown method "FETCH"`) {
+		t.Errorf("err = %v, want a usage error", err)
+	}
+	if len(f.reqs) != 0 {
+		t.Errorf("sent %d requests", len(f.reqs))
+	}
+}
+
+func TestAPICmdGetNeedsNoForce(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("GET /rest/api/3/myself", 200, map[string]any{"accountId": "acc-1"})
+	var err error
+	captureStdout(t, func() { err = apiCmd(f.config(), []string{"get", "/rest/api/3/myself"}) })
+	if err != nil || len(f.requests("GET", "/rest/api/3/myself")) != 1 {
+		t.Errorf("GET without -force: err = %v", err)
+	}
+}
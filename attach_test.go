@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAttachMultipart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "build.log")
+	os.WriteFile(path, []byte("line 1\nline 2\n"), 0o644)
+
+	f := newFakeJira(t)
+	var token, filename, content string
+	f.mux.HandleFunc("POST /rest/api/3/issue/IS-1/attachments", func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("X-Atlassian-Token")
+		file, hdr, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("reading the file part: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		buf, _ := io.ReadAll(file)
+		filename, content = hdr.Filename, string(buf)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id": "10001", "filename": "build.log", "size": 14}]`))
+	})
+
+	var err error
+	out := captureStdout(t, func() { err = attachCmd(f.config(), []string{"IS-1", path}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "no-check" {
+		t.Errorf("X-Atlassian-Token = %q, want no-check", token)
+	}
+	if filename != "build.log" || content != "line 1\nline 2\n" {
+		t.Errorf("uploaded %q with %q", filename, content)
+	}
+	if out != "Attached build.log to IS-1 (id 10001, 14 B)\n" {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestCheckAttachable(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty")
+	os.WriteFile(empty, nil, 0o644)
+	for _, path := range []string{filepath.Join(dir, "missing"), dir, empty} {
+		if err := checkAttachable(path); !errors.Is(err, ErrUsage) {
+			t.Errorf("checkAttachable(%s): err = %v, want a usage error", path, err)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+)
+
+// failFast stops batch operations at the first failing item instead of
+// carrying on and reporting every failure at the end.
+var failFast bool
+
+// resultsPath and resumePath are -results and -resume: where a batch
+// records each key's outcome, and an earlier record whose successes are
+// skipped. -resume alone writes back to the file it read.
+var resultsPath, resumePath string
+
+// batchResult is one key's outcome in a results file, which holds a JSON
+// array of them in batch order.
+type batchResult struct {
+	Key   string `json:"key"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+func loadResults(path string) ([]batchResult, error) {
+	buf, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rs []batchResult
+	if err := json.Unmarshal(buf, &rs); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rs, nil
+}
+
+func saveResults(path string, rs []batchResult) error {
+	buf, err := json.MarshalIndent(rs, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write beside the target and rename, so an interrupted run never
+	// leaves a truncated file to resume from.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(buf, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// runBatch calls fn for each key. With failFast it returns the first
+// error; otherwise it tries every key and returns all failures combined,
+// each prefixed with its key. Keys that succeeded in the -resume file are
+// skipped, and every outcome is saved to the results file as it happens.
+func runBatch(keys []string, fn func(key string) error) error {
+	done := map[string]bool{}
+	if resumePath != "" {
+		prev, err := loadResults(resumePath)
+		if err != nil {
+			return err
+		}
+		for _, r := range prev {
+			done[r.Key] = done[r.Key] || r.OK
+		}
+	}
+	out := resultsPath
+	if out == "" {
+		out = resumePath
+	}
+
+	var results []batchResult
+	record := func(r batchResult) error {
+		results = append(results, r)
+		if out == "" {
+			return nil
+		}
+		return saveResults(out, results)
+	}
+
+	var errs []error
+	for _, k := range keys {
+		if done[k] {
+			fmt.Printf("Skipped %s: done in an earlier run\n", k)
+			if err := record(batchResult{Key: k, OK: true}); err != nil {
+				return err
+			}
+			continue
+		}
+		err := fn(k)
+		r := batchResult{Key: k, OK: err == nil}
+		if err != nil {
+			r.Error = err.Error()
+		}
+		if werr := record(r); werr != nil {
+			return werr
+		}
+		if err != nil {
+			err = fmt.Errorf("%s: %w", k, err)
+			if failFast {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d failed:\n%w", len(errs), len(keys), errors.Join(errs...))
+}
+
+// batchSize is -batch-size: how many issues each sprint or backlog
+// request carries. The agile endpoints reject more than 50 at once.
+var batchSize = 50
+
+// inChunks calls fn with keys batchSize at a time, one request each;
+// the rate limiter paces them like any other. It aggregates failures
+// as runBatch does, each prefixed with its chunk's first and last key,
+// and stops at the first one with failFast.
+func inChunks(keys []string, fn func(chunk []string) error) error {
+	if batchSize < 1 {
+		return usageError("-batch-size must be at least 1, got %d", batchSize)
+	}
+	if len(keys) <= batchSize {
+		return fn(keys)
+	}
+	var errs []error
+	n := 0
+	for chunk := range slices.Chunk(keys, batchSize) {
+		n++
+		if err := fn(chunk); err != nil {
+			err = fmt.Errorf("%s…%s (%d issues): %w", chunk[0], chunk[len(chunk)-1], len(chunk), err)
+			if failFast {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d batches failed:\n%w", len(errs), n, errors.Join(errs...))
+}
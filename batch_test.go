@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var errBoom = errors.New("boom")
+
+// failOn returns a batch function that fails for bad and records every
+// key it was called with.
+func failOn(bad string, called *[]string) func(string) error {
+	return func(k string) error {
+		*called = append(*called, k)
+		if k == bad {
+			return errBoom
+		}
+		return nil
+	}
+}
+
+func TestRunBatchContinuesByDefault(t *testing.T) {
+	setGlobal(t, &failFast, false)
+	var called []string
+	var err error
+	captureStdout(t, func() { err = runBatch([]string{"IS-1", "IS-2", "IS-3"}, failOn("IS-2", &called)) })
+	if !slices.Equal(called, []string{"IS-1", "IS-2", "IS-3"}) {
+		t.Errorf("called %v, want every key", called)
+	}
+	if !errors.Is(err, errBoom) || !strings.HasPrefix(err.Error(), "1 of 3 failed:\nIS-2: boom") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestRunBatchFailFast(t *testing.T) {
+	setGlobal(t, &failFast, true)
+	var called []string
+	var err error
+	captureStdout(t, func() { err = runBatch([]string{"IS-1", "IS-2", "IS-3"}, failOn("IS-2", &called)) })
+	if !slices.Equal(called, []string{"IS-1", "IS-2"}) {
+		t.Errorf("called %v, want to stop after IS-2", called)
+	}
+	if !errors.Is(err, errBoom) || err.Error() != "IS-2: boom" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestRunBatchResume(t *testing.T) {
+	setGlobal(t, &failFast, false)
+	path := filepath.Join(t.TempDir(), "results.json")
+	setGlobal(t, &resultsPath, "")
+	setGlobal(t, &resumePath, path)
+	keys := []string{"IS-1", "IS-2", "IS-3"}
+
+	var called []string
+	captureStdout(t, func() { runBatch(keys, failOn("IS-2", &called)) })
+	rs, err := loadResults(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []batchResult{{"IS-1", true, ""}, {"IS-2", false, "boom"}, {"IS-3", true, ""}}; !slices.Equal(rs, want) {
+		t.Errorf("results = %+v, want %+v", rs, want)
+	}
+
+	// The second run only retries the failure.
+	called = nil
+	out := captureStdout(t, func() { err = runBatch(keys, failOn("", &called)) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(called, []string{"IS-2"}) {
+		t.Errorf("resumed run called %v, want only IS-2", called)
+	}
+	if !strings.Contains(out, "Skipped IS-1: done in an earlier run") {
+		t.Errorf("output = %q", out)
+	}
+	if rs, _ := loadResults(path); len(rs) != 3 || !rs[0].OK || !rs[1].OK || !rs[2].OK {
+		t.Errorf("results after resume = %+v, want all ok", rs)
+	}
+}
+
+func TestAddIssuesToSprintInChunks(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("POST /rest/agile/1.0/sprint/7/issue", 204, nil)
+	setGlobal(t, &batchSize, 50)
+	keys := make([]string, 120)
+	for i := range keys {
+		keys[i] = "IS-" + strconv.Itoa(i+1)
+	}
+
+	if err := addIssuesToSprint(f.config(), 7, keys); err != nil {
+		t.Fatal(err)
+	}
+	reqs := f.requests("POST", "/rest/agile/1.0/sprint/7/issue")
+	if len(reqs) != 3 {
+		t.Fatalf("%d requests, want 3", len(reqs))
+	}
+	var sent []string
+	for i, want := range []int{50, 50, 20} {
+		var body struct{ Issues []string }
+		if err := json.Unmarshal([]byte(reqs[i].Body), &body); err != nil {
+			t.Fatal(err)
+		}
+		if len(body.Issues) != want {
+			t.Errorf("request %d carries %d issues, want %d", i+1, len(body.Issues), want)
+		}
+		sent = append(sent, body.Issues...)
+	}
+	if !slices.Equal(sent, keys) {
+		t.Errorf("chunks do not add up to the keys in order: %v", sent)
+	}
+}
+
+func TestInChunksAggregatesFailures(t *testing.T) {
+	setGlobal(t, &batchSize, 2)
+	setGlobal(t, &failFast, false)
+	var calls int
+	err := inChunks([]string{"IS-1", "IS-2", "IS-3", "IS-4", "IS-5"}, func(chunk []string) error {
+		calls++
+		if chunk[0] == "IS-3" {
+			return errBoom
+		}
+		return nil
+	})
+	if calls != 3 || !errors.Is(err, errBoom) || !strings.Contains(err.Error(), "1 of 3 batches failed") || !strings.Contains(err.Error(), "IS-3…IS-4 (2 issues)") {
+		t.Errorf("calls = %d, err = %v", calls, err)
+	}
+
+	batchSize = 0
+	if err := inChunks([]string{"IS-1"}, func([]string) error { return nil }); !errors.Is(err, ErrUsage) {
+		t.Errorf("-batch-size 0: err = %v, want a usage error", err)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// boardScope is -board and -sprint: list what a board shows, through its
+// own filter, instead of running the assigned-issues query.
+type boardScope struct {
+	BoardID int
+	Sprint  string
+}
+
+// boardIssuesPath is the agile endpoint listing a board's issues, or one
+// of its sprints' when sprintID is set.
+func boardIssuesPath(boardID, sprintID int) []string {
+	if sprintID != 0 {
+		return []string{"rest/agile/1.0/sprint", strconv.Itoa(sprintID), "issue"}
+	}
+	return []string{"rest/agile/1.0/board", strconv.Itoa(boardID), "issue"}
+}
+
+// boardSprintID finds the active or future sprint called name on the
+// board.
+func boardSprintID(cfg JiraConfig, boardID int, name string) (int, error) {
+	sprints, err := getBoardSprints(cfg, boardID)
+	if err != nil {
+		return 0, err
+	}
+	for _, sp := range sprints {
+		if strings.EqualFold(sp.Name, name) {
+			return sp.ID, nil
+		}
+	}
+	return 0, usageError("board %d has no active or future sprint named %q", boardID, name)
+}
+
+// boardIssuesEach is searchIssuesEach for a board or sprint endpoint,
+// which pages with startAt/total. jql, when set, narrows the board's
+// own filter rather than replacing it.
+func boardIssuesEach(cfg JiraConfig, parts []string, jql string, fn func([]JiraIssue) error) error {
+	pageSize := clampPageSize(cfg.PageSize)
+	seen := 0
+	prog := newProgress()
+	defer prog.done()
+	defer atInterrupt(prog.done)()
+	for {
+		prog.next(seen)
+		q := url.Values{
+			"startAt":    {strconv.Itoa(seen)},
+			"maxResults": {strconv.Itoa(pageSize)},
+			"fields":     {strings.Join(searchFields, ",")},
+		}
+		if jql != "" {
+			q.Set("jql", jql)
+		}
+		var page searchPage
+		if err := doJSON(cfg, http.MethodGet, apiURL(cfg, q, parts...), nil, &page); err != nil {
+			return err
+		}
+		seen += len(page.Issues)
+		prog.done()
+		if err := fn(page.Issues); err != nil {
+			return err
+		}
+		if len(page.Issues) == 0 || seen >= page.Total {
+			return nil
+		}
+	}
+}
+
+// issuesEach streams the issues a listing covers: the board's when
+// scope names one, else those matching jql.
+func (scope boardScope) issuesEach(cfg JiraConfig, jql string, fn func([]JiraIssue) error) error {
+	if scope.BoardID == 0 {
+		return searchIssuesEach(cfg, jql, fn)
+	}
+	parts, err := scope.path(cfg)
+	if err != nil {
+		return err
+	}
+	return boardIssuesEach(cfg, parts, jql, fn)
+}
+
+func (scope boardScope) issues(cfg JiraConfig, jql string) ([]JiraIssue, error) {
+	issues := []JiraIssue{}
+	err := scope.issuesEach(cfg, jql, func(page []JiraIssue) error {
+		issues = append(issues, page...)
+		return nil
+	})
+	return issues, err
+}
+
+func (scope boardScope) path(cfg JiraConfig) ([]string, error) {
+	sprintID := 0
+	if scope.Sprint != "" {
+		id, err := boardSprintID(cfg, scope.BoardID, scope.Sprint)
+		if err != nil {
+			return nil, err
+		}
+		sprintID = id
+	}
+	return boardIssuesPath(scope.BoardID, sprintID), nil
+}
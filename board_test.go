@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBoardIssuesPath(t *testing.T) {
+	tests := []struct {
+		board, sprint int
+		want          []string
+	}{
+		{42, 0, []string{"rest/agile/1.0/board", "42", "issue"}},
+		{42, 7, []string{"rest/agile/1.0/sprint", "7", "issue"}},
+	}
+	for _, tt := range tests {
+		if got := boardIssuesPath(tt.board, tt.sprint); !slices.Equal(got, tt.want) {
+			t.Errorf("boardIssuesPath(%d, %d) = %q, want %q", tt.board, tt.sprint, got, tt.want)
+		}
+	}
+}
+
+func TestBoardScopeIssues(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("GET /rest/agile/1.0/board/42/issue", 200, map[string]any{
+		"issues": []JiraIssue{sampleIssue("IS-1", "Open", 2), sampleIssue("IS-2", "Done", 3)},
+		"total":  2,
+	})
+
+	issues, err := boardScope{BoardID: 42}.issues(f.config(), "status != Closed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[0].Key != "IS-1" || issues[1].Fields.Points != 3 {
+		t.Errorf("issues = %+v", issues)
+	}
+	reqs := f.requests("GET", "/rest/agile/1.0/board/42/issue")
+	if len(reqs) != 1 || reqs[0].Query.Get("jql") != "status != Closed" || reqs[0].Query.Get("startAt") != "0" {
+		t.Errorf("requests = %+v, want one narrowed by the JQL", reqs)
+	}
+}
+
+func TestBoardScopeSprint(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("GET /rest/agile/1.0/board/42/sprint", 200, map[string]any{
+		"values": []Sprint{{ID: 6, Name: "Sprint 6", State: "active"}, {ID: 7, Name: "Sprint 7", State: "future"}},
+		"isLast": true,
+	})
+	f.reply("GET /rest/agile/1.0/sprint/7/issue", 200, map[string]any{
+		"issues": []JiraIssue{sampleIssue("IS-3", "Open", 0)},
+		"total":  1,
+	})
+
+	issues, err := boardScope{BoardID: 42, Sprint: "sprint 7"}.issues(f.config(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Key != "IS-3" {
+		t.Errorf("issues = %+v", issues)
+	}
+	if len(f.requests("GET", "/rest/agile/1.0/board/42/issue")) != 0 {
+		t.Error("listed the whole board for a sprint scope")
+	}
+
+	_, err = boardScope{BoardID: 42, Sprint: "Sprint 9"}.issues(f.config(), "")
+	if !errors.Is(err, ErrUsage) {
+		t.Errorf("unknown sprint: err = %v, want a usage error", err)
+	}
+}
+
+func TestBoardIssuesPages(t *testing.T) {
+	f := newFakeJira(t)
+	f.mux.HandleFunc("GET /rest/agile/1.0/board/42/issue", func(w http.ResponseWriter, r *http.Request) {
+		key := "IS-1"
+		if r.URL.Query().Get("startAt") == "1" {
+			key = "IS-2"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"issues": []JiraIssue{sampleIssue(key, "Open", 0)}, "total": 2})
+	})
+
+	issues, err := boardScope{BoardID: 42}.issues(f.config(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[1].Key != "IS-2" {
+		t.Errorf("issues = %+v, want both pages", issues)
+	}
+}
+
+func TestBoardListingKeepsQueryFlags(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("GET /rest/agile/1.0/board/42/issue", 200, map[string]any{
+		"issues": []JiraIssue{sampleIssue("IS-1", "Open", 0)},
+		"total":  1,
+	})
+
+	lo := listOptions{
+		Board:      42,
+		Team:       optionalString{Given: true, Value: "devs"},
+		Category:   "inprogress",
+		NoSubtasks: true,
+		JQL:        "labels = api OR labels = web",
+		Output:     "keys",
+	}
+	var err error
+	captureStdout(t, func() { err = listFlow(f.config(), lo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs := f.requests("GET", "/rest/agile/1.0/board/42/issue")
+	want := `assignee in membersOf("devs") AND statusCategory = "indeterminate" AND issuetype not in subtaskIssueTypes() AND (labels = api OR labels = web)`
+	if len(reqs) != 1 || reqs[0].Query.Get("jql") != want {
+		t.Errorf("board requests = %+v, want jql %s", reqs, want)
+	}
+
+	err = listFlow(f.config(), listOptions{Board: 42, Epics: true})
+	if !errors.Is(err, ErrUsage) || !strings.Contains(err.Error(), "-include-epics") {
+		t.Errorf("-board with -include-epics: err = %v, want a usage error", err)
+	}
+}
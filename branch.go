@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// gitBranch returns the current git branch name. It is a variable so the
+// git invocation can be replaced.
+var gitBranch = func() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// issueKeyPattern matches an issue key in upper case, as Jira writes it.
+// The key is the first submatch. The boundaries are spelled out because
+// \b counts _ as a word character, which would miss PROJ-123_fix and
+// match FOO_PROJ-1 from the wrong place.
+var issueKeyPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9])([A-Z][A-Z0-9]+-[1-9][0-9]*)(?:[^0-9]|$)`)
+
+// findIssueKey returns the first issue key in s, or "".
+func findIssueKey(s string) string {
+	if m := issueKeyPattern.FindStringSubmatch(s); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// keyFromBranch finds an issue key in a branch name such as
+// feature/PROJ-1234-thing. Lower-case keys are only recognised for
+// defaultProject, since something like release-2024 would match too.
+func keyFromBranch(branch, defaultProject string) string {
+	if k := findIssueKey(branch); k != "" {
+		return k
+	}
+	if defaultProject == "" {
+		return ""
+	}
+	re := regexp.MustCompile(`(?i)(?:^|[^a-z0-9])(` + regexp.QuoteMeta(defaultProject) + `-[1-9][0-9]*)(?:[^0-9]|$)`)
+	if m := re.FindStringSubmatch(branch); m != nil {
+		return strings.ToUpper(m[1])
+	}
+	return ""
+}
+
+// branchKey is the issue key named by the current git branch, for -branch.
+func branchKey(cfg JiraConfig) (string, error) {
+	branch, err := gitBranch()
+	if err != nil {
+		return "", usageError("-branch: not in a git repository")
+	}
+	k := keyFromBranch(branch, cfg.DefaultProject)
+	if k == "" {
+		return "", usageError("-branch: no issue key in branch %q", branch)
+	}
+	return k, nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestKeyFromBranch(t *testing.T) {
+	tests := []struct {
+		branch, project, want string
+	}{
+		{"feature/PROJ-1234-thing", "", "PROJ-1234"},
+		{"PROJ-7", "", "PROJ-7"},
+		{"bugfix/ABC_2-15_fix", "", ""},
+		{"PROJ-123_fix-login", "", "PROJ-123"},
+		{"feature/PROJ-12_x", "", "PROJ-12"},
+		{"feature/FOO_PROJ-1", "", "PROJ-1"},
+		{"fix_PROJ-9", "", "PROJ-9"},
+		{"feature/proj-12_lower", "PROJ", "PROJ-12"},
+		{"feature/xproj-12", "PROJ", ""},
+		{"feature/proj-12-lower", "PROJ", "PROJ-12"},
+		{"feature/proj-12-lower", "", ""},
+		{"release-2024", "", ""},
+		{"release-2024", "PROJ", ""},
+		{"main", "PROJ", ""},
+	}
+	for _, tt := range tests {
+		if got := keyFromBranch(tt.branch, tt.project); got != tt.want {
+			t.Errorf("keyFromBranch(%q, %q) = %q, want %q", tt.branch, tt.project, got, tt.want)
+		}
+	}
+}
+
+func TestTransitionFromBranch(t *testing.T) {
+	f := newFakeJira(t)
+	f.replyTransitions("In Progress:indeterminate")
+	setGlobal(t, &gitBranch, func() (string, error) { return "feature/IS-42-login", nil })
+
+	var err error
+	captureStdout(t, func() { err = transitionCmd(f.config(), []string{"-branch", "In", "Progress"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.transitioned(); !slices.Equal(got, []string{"IS-42"}) {
+		t.Errorf("transitioned %q, want IS-42", got)
+	}
+
+	for _, args := range [][]string{{"-branch", "IS-1", "Done"}, {"-branch", "is-1", "Done"}, {"-branch", "-", "Done"}} {
+		if err := transitionCmd(f.config(), args); !errors.Is(err, ErrUsage) {
+			t.Errorf("%q: err = %v, want a usage error", args, err)
+		}
+	}
+
+	gitBranch = func() (string, error) { return "main", nil }
+	if err := transitionCmd(f.config(), []string{"-branch", "Done"}); !errors.Is(err, ErrUsage) {
+		t.Errorf("branch without a key: err = %v, want a usage error", err)
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cacheTTL is how long a read stays fresh in the response cache; zero,
+// the default, turns the cache off. Set from JIRA_CACHE_TTL or
+// -cache-ttl, and -no-cache bypasses it for one run.
+var cacheTTL time.Duration
+
+var noCache bool
+
+// cacheNow is the cache's clock, replaceable for testing.
+var cacheNow = time.Now
+
+// cacheEntry is one stored response. Stale entries with an ETag are
+// revalidated with If-None-Match instead of being refetched in full.
+type cacheEntry struct {
+	Stored time.Time       `json:"stored"`
+	ETag   string          `json:"etag,omitempty"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// cacheable reports whether req is a read: a GET, or a POST to one of
+// the search endpoints, which take their query as a body.
+func cacheable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet:
+		return true
+	case http.MethodPost:
+		return strings.HasSuffix(req.URL.Path, searchJQLPath) || strings.HasSuffix(req.URL.Path, searchLegacyPath)
+	}
+	return false
+}
+
+func responseCacheDir() (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "responses"), nil
+}
+
+// cachePath keys an entry by account, method, URL and body, so two
+// accounts on one machine never see each other's responses.
+func cachePath(cfg JiraConfig, req *http.Request) (string, error) {
+	dir, err := responseCacheDir()
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	io.WriteString(h, cfg.Email+"\n"+req.Method+" "+req.URL.String()+"\n")
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return "", err
+		}
+		io.Copy(h, body)
+	}
+	return filepath.Join(dir, hex.EncodeToString(h.Sum(nil))+".json"), nil
+}
+
+func loadCacheEntry(path string) *cacheEntry {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var e cacheEntry
+	if json.Unmarshal(buf, &e) != nil {
+		return nil
+	}
+	return &e
+}
+
+// saveCacheEntry writes e readable only by the user, since responses
+// hold whatever the account can see.
+func saveCacheEntry(path string, e *cacheEntry) error {
+	buf, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// clearResponseCache drops every stored response. It runs after each
+// successful write so a listing right after a transition isn't stale.
+func clearResponseCache() {
+	dir, err := responseCacheDir()
+	if err != nil {
+		return
+	}
+	if err := os.RemoveAll(dir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		debugf("clearing response cache: %v", err)
+	}
+}
+
+// cachedResponse is doRequest for reads while the cache is on: a fresh
+// entry is served without a request, a stale one is revalidated by ETag
+// when it has one, and a 2xx response is stored.
+func cachedResponse(cfg JiraConfig, req *http.Request, out any) error {
+	path, err := cachePath(cfg, req)
+	if err != nil {
+		return err
+	}
+	entry := loadCacheEntry(path)
+	if entry != nil && cacheNow().Sub(entry.Stored) < cacheTTL {
+		debugf("cache hit: %s %s", req.Method, req.URL.Path)
+		return decodeBody(entry.Body, out)
+	}
+	if entry != nil && entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	res, err := send(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotModified && entry != nil {
+		debugf("cache revalidated: %s %s", req.Method, req.URL.Path)
+		entry.Stored = cacheNow()
+		if err := saveCacheEntry(path, entry); err != nil {
+			debugf("writing response cache: %v", err)
+		}
+		return decodeBody(entry.Body, out)
+	}
+	if err := checkStatus(res); err != nil {
+		return err
+	}
+	if err := checkJSON(res); err != nil {
+		return err
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if json.Valid(body) {
+		e := &cacheEntry{Stored: cacheNow(), ETag: res.Header.Get("ETag"), Body: body}
+		if err := saveCacheEntry(path, e); err != nil {
+			debugf("writing response cache: %v", err)
+		}
+	}
+	return decodeBody(body, out)
+}
+
+func decodeBody(body []byte, out any) error {
+	if out == nil || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, out)
+}
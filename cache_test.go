@@ -0,0 +1,82 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// useTestCache points the response cache at a temporary directory with
+// the given TTL and returns a settable clock.
+func useTestCache(t *testing.T, ttl time.Duration) *time.Time {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	setGlobal(t, &cacheTTL, ttl)
+	setGlobal(t, &noCache, false)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	setGlobal(t, &cacheNow, func() time.Time { return now })
+	return &now
+}
+
+func TestCacheHitAndExpiry(t *testing.T) {
+	now := useTestCache(t, time.Minute)
+	f := newFakeJira(t)
+	f.reply("GET /rest/api/3/myself", 200, map[string]any{"accountId": "acc-1", "displayName": "Ada"})
+
+	get := func() {
+		t.Helper()
+		u, err := getMyself(f.config())
+		if err != nil || u.DisplayName != "Ada" {
+			t.Fatalf("getMyself = %+v, %v", u, err)
+		}
+	}
+	get()
+	*now = now.Add(30 * time.Second)
+	get()
+	if n := len(f.requests("GET", "/rest/api/3/myself")); n != 1 {
+		t.Errorf("within the TTL: %d requests, want 1", n)
+	}
+	*now = now.Add(time.Minute)
+	get()
+	if n := len(f.requests("GET", "/rest/api/3/myself")); n != 2 {
+		t.Errorf("after expiry: %d requests, want 2", n)
+	}
+}
+
+func TestCacheRevalidatesByETag(t *testing.T) {
+	now := useTestCache(t, time.Minute)
+	f := newFakeJira(t)
+	f.mux.HandleFunc("GET /rest/api/3/myself", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"accountId": "acc-1", "displayName": "Ada"}`))
+	})
+
+	getMyself(f.config())
+	*now = now.Add(2 * time.Minute)
+	u, err := getMyself(f.config())
+	if err != nil || u.DisplayName != "Ada" {
+		t.Fatalf("revalidated getMyself = %+v, %v", u, err)
+	}
+	reqs := f.requests("GET", "/rest/api/3/myself")
+	if len(reqs) != 2 || reqs[1].Header.Get("If-None-Match") != `"v1"` {
+		t.Errorf("requests = %+v, want a conditional second request", reqs)
+	}
+}
+
+func TestNoCacheBypasses(t *testing.T) {
+	useTestCache(t, time.Minute)
+	noCache = true
+	f := newFakeJira(t)
+	f.reply("GET /rest/api/3/myself", 200, map[string]any{"accountId": "acc-1"})
+	getMyself(f.config())
+	getMyself(f.config())
+	if n := len(f.requests("GET", "/rest/api/3/myself")); n != 2 {
+		t.Errorf("-no-cache: %d requests, want 2", n)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type cloneSource struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary   string `json:"summary"`
+		IssueType struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+		Project struct {
+			Key string `json:"key"`
+		} `json:"project"`
+		Description json.RawMessage `json:"description"`
+		Labels      []string        `json:"labels"`
+		Points      *float64        `json:"customfield_10004"`
+	} `json:"fields"`
+}
+
+func getCloneSource(cfg JiraConfig, issueKey string) (*cloneSource, error) {
+	var src cloneSource
+	q := url.Values{"fields": {"summary,issuetype,project,description,labels,customfield_10004"}}
+	u := apiURL(cfg, q, "rest/api/3/issue", url.PathEscape(issueKey))
+	if err := doJSON(cfg, http.MethodGet, u, nil, &src); err != nil {
+		return nil, err
+	}
+	return &src, nil
+}
+
+// cloneOptions copies the fields of src that a clone should carry over.
+func cloneOptions(src *cloneSource, summary string) createOptions {
+	f := src.Fields
+	if summary == "" {
+		summary = f.Summary
+	}
+	return createOptions{
+		Project:     f.Project.Key,
+		Type:        f.IssueType.Name,
+		Summary:     summary,
+		Labels:      f.Labels,
+		Points:      f.Points,
+		Description: f.Description,
+	}
+}
+
+// linkClone records that clone was cloned from original. Jira reads a
+// link as "inwardIssue <outward description> outwardIssue", i.e. the
+// clone "clones" the original.
+func linkClone(cfg JiraConfig, clone, original string) error {
+	body := map[string]any{
+		"type":         map[string]any{"name": "Cloners"},
+		"inwardIssue":  map[string]any{"key": clone},
+		"outwardIssue": map[string]any{"key": original},
+	}
+	return doJSON(cfg, http.MethodPost, apiURL(cfg, nil, "rest/api/3/issueLink"), body, nil)
+}
+
+func cloneCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("clone")
+	summary := fs.String("summary", "", "summary for the new issue (default: copy the original)")
+	pos := parseArgs(fs, args)
+	if len(pos) != 1 {
+		return usageError("usage: jira-cli clone <KEY> [-summary TEXT]")
+	}
+
+	src, err := getCloneSource(cfg, expandKey(cfg, pos[0]))
+	if err != nil {
+		return err
+	}
+
+	key, err := createIssue(cfg, createFields(cloneOptions(src, *summary)))
+	if err != nil {
+		return err
+	}
+	if err := linkClone(cfg, key, src.Key); err != nil {
+		return fmt.Errorf("created %s but could not link it to %s: %w", key, src.Key, err)
+	}
+	fmt.Printf("Created %s (clone of %s)\n", key, src.Key)
+	return nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCloneCopiesFetchedSource(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("GET /rest/api/3/issue/PROJ-1", 200, map[string]any{
+		"key": "PROJ-1",
+		"fields": map[string]any{
+			"summary":           "Login fails",
+			"issuetype":         map[string]any{"name": "Bug"},
+			"project":           map[string]any{"key": "PROJ"},
+			"description":       map[string]any{"type": "doc", "version": 1, "content": []any{}},
+			"labels":            []string{"ui"},
+			"customfield_10004": 3,
+		},
+	})
+	f.reply("POST /rest/api/3/issue", 201, map[string]any{"key": "PROJ-9"})
+	f.reply("POST /rest/api/3/issueLink", 201, nil)
+
+	var err error
+	out := captureStdout(t, func() { err = cloneCmd(f.config(), []string{"PROJ-1"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	creates := f.requests("POST", "/rest/api/3/issue")
+	if len(creates) != 1 {
+		t.Fatalf("%d create requests, want 1", len(creates))
+	}
+	want := `{"fields":{"customfield_10004":3,"description":{"content":[],"type":"doc","version":1},` +
+		`"issuetype":{"name":"Bug"},"labels":["ui"],"project":{"key":"PROJ"},"summary":"Login fails"}}`
+	if creates[0].Body != want {
+		t.Errorf("create body:\n got  %s\n want %s", creates[0].Body, want)
+	}
+	links := f.requests("POST", "/rest/api/3/issueLink")
+	if len(links) != 1 || !strings.Contains(links[0].Body, `"inwardIssue":{"key":"PROJ-9"}`) ||
+		!strings.Contains(links[0].Body, `"outwardIssue":{"key":"PROJ-1"}`) {
+		t.Errorf("link requests = %+v", links)
+	}
+	if !strings.Contains(out, "Created PROJ-9 (clone of PROJ-1)") {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestCloneOptionsSummaryOverride(t *testing.T) {
+	src := &cloneSource{}
+	src.Fields.Summary = "Original"
+	if got := cloneOptions(src, "").Summary; got != "Original" {
+		t.Errorf("default summary = %q, want Original", got)
+	}
+	if got := cloneOptions(src, "Copy").Summary; got != "Copy" {
+		t.Errorf("-summary = %q, want Copy", got)
+	}
+}
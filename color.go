@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var ansiColors = map[string]string{
+	"bold":    "1",
+	"red":     "31",
+	"green":   "32",
+	"yellow":  "33",
+	"blue":    "34",
+	"magenta": "35",
+	"cyan":    "36",
+	"gray":    "90",
+}
+
+func colorEnabled(f *os.File) bool {
+	return isTerminal(f) && os.Getenv("NO_COLOR") == "" && !activeTheme.Mono
+}
+
+func colorize(code, s string) string {
+	if code == "" {
+		return s
+	}
+	return "\x1b[" + code + "m" + s + "\x1b[0m"
+}
+
+// labelStyles reads the [label_colors] and [label_priority] config
+// sections. Labels are matched case-insensitively.
+func labelStyles(c fileConfig) (colors map[string]string, priority map[string]int, err error) {
+	for label, name := range c.Section("label_colors") {
+		code, ok := ansiColors[strings.ToLower(name)]
+		if !ok {
+			return nil, nil, fmt.Errorf("label_colors: unknown color %q for %q", name, label)
+		}
+		if colors == nil {
+			colors = map[string]string{}
+		}
+		colors[strings.ToLower(label)] = code
+	}
+	for label, v := range c.Section("label_priority") {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("label_priority: %q is not a number", v)
+		}
+		if priority == nil {
+			priority = map[string]int{}
+		}
+		priority[strings.ToLower(label)] = n
+	}
+	return colors, priority, nil
+}
+
+// labelColor returns the color of the first of ji's labels that has one.
+func labelColor(ji JiraIssue, colors map[string]string) string {
+	for _, l := range ji.Fields.Labels {
+		if c, ok := colors[strings.ToLower(l)]; ok {
+			return c
+		}
+	}
+	return ""
+}
+
+// labelRank is the lowest configured priority among ji's labels; issues
+// without one sort last.
+func labelRank(ji JiraIssue, priority map[string]int) int {
+	rank := math.MaxInt
+	for _, l := range ji.Fields.Labels {
+		if p, ok := priority[strings.ToLower(l)]; ok && p < rank {
+			rank = p
+		}
+	}
+	return rank
+}
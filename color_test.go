@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLabelPrioritySort(t *testing.T) {
+	c, err := parseConfig(strings.NewReader("[label_priority]\nUrgent = 0\nblocked = 1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, priority, err := labelStyles(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plain, blocked, urgent, both := sampleIssue("IS-1", "Open", 1), sampleIssue("IS-2", "Open", 1),
+		sampleIssue("IS-3", "Open", 1), sampleIssue("IS-4", "Open", 1)
+	blocked.Fields.Labels = []string{"ui", "blocked"}
+	urgent.Fields.Labels = []string{"URGENT"}
+	both.Fields.Labels = []string{"blocked", "urgent"}
+	issues := []JiraIssue{plain, blocked, urgent, both}
+
+	order := func(opts formatOptions) []string {
+		var keys []string
+		for _, line := range strings.Split(formatIssuesBySprint(issues, opts), "\n") {
+			if k, _, ok := strings.Cut(strings.TrimSpace(line), "\t"); ok && strings.HasPrefix(k, "IS-") {
+				keys = append(keys, k)
+			}
+		}
+		return keys
+	}
+	// Ties keep their listing order.
+	if got, want := strings.Join(order(formatOptions{LabelPriority: priority}), " "), "IS-3 IS-4 IS-2 IS-1"; got != want {
+		t.Errorf("with priorities: %s, want %s", got, want)
+	}
+	if got, want := strings.Join(order(formatOptions{}), " "), "IS-1 IS-2 IS-3 IS-4"; got != want {
+		t.Errorf("without priorities: %s, want %s", got, want)
+	}
+
+	if _, _, err := labelStyles(fileConfig{"label_priority": {"urgent": "high"}}); err == nil {
+		t.Error("a non-numeric priority was accepted")
+	}
+}
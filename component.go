@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type Component struct {
+	Name string `json:"name"`
+}
+
+func componentNames(cs []Component) string {
+	names := make([]string, len(cs))
+	for i, c := range cs {
+		names[i] = c.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func getProjectComponents(cfg JiraConfig, projectKey string) ([]Component, error) {
+	var cs []Component
+	u := apiURL(cfg, nil, "rest/api/3/project", url.PathEscape(projectKey), "components")
+	err := doJSON(cfg, http.MethodGet, u, nil, &cs)
+	return cs, err
+}
+
+// resolveComponents matches names case-insensitively against the
+// project's components and returns them with the project's spelling.
+func resolveComponents(names []string, valid []Component) ([]Component, error) {
+	var out []Component
+	var unknown []string
+	for _, n := range names {
+		found := false
+		for _, c := range valid {
+			if strings.EqualFold(c.Name, n) {
+				out = append(out, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknown = append(unknown, fmt.Sprintf("%q", n))
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, usageError("unknown component %s (valid: %s)", strings.Join(unknown, ", "), orDash(componentNames(valid)))
+	}
+	return out, nil
+}
+
+// componentFields is the PUT payload replacing an issue's components.
+func componentFields(cs []Component) map[string]any {
+	if cs == nil {
+		cs = []Component{}
+	}
+	return map[string]any{"fields": map[string]any{"components": cs}}
+}
+
+func componentCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("component")
+	clearAll := fs.Bool("clear", false, "remove all components")
+	pos := parseArgs(fs, args)
+	if len(pos) < 1 || (len(pos) == 1) != *clearAll {
+		return usageError("usage: jira-cli component <KEY> <name>... | -clear <KEY>")
+	}
+	key := expandKey(cfg, pos[0])
+
+	var cs []Component
+	if !*clearAll {
+		ji, err := getIssue(cfg, key, "project")
+		if err != nil {
+			return err
+		}
+		valid, err := getProjectComponents(cfg, ji.Fields.Project.Key)
+		if err != nil {
+			return err
+		}
+		if cs, err = resolveComponents(pos[1:], valid); err != nil {
+			return err
+		}
+	}
+
+	u := apiURL(cfg, nil, "rest/api/3/issue", url.PathEscape(key))
+	if err := doJSON(cfg, http.MethodPut, u, componentFields(cs), nil); err != nil {
+		return err
+	}
+	if len(cs) == 0 {
+		fmt.Printf("Cleared components on %s\n", key)
+	} else {
+		fmt.Printf("Set components of %s to %s\n", key, componentNames(cs))
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestComponentSet(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("GET /rest/api/3/issue/IS-1", 200, map[string]any{"key": "IS-1", "fields": map[string]any{"project": map[string]any{"key": "IS"}}})
+	f.reply("GET /rest/api/3/project/IS/components", 200, []Component{{"Backend"}, {"Web UI"}})
+	f.reply("PUT /rest/api/3/issue/IS-1", 204, nil)
+
+	var err error
+	out := captureStdout(t, func() { err = componentCmd(f.config(), []string{"IS-1", "web ui", "BACKEND"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	puts := f.requests("PUT", "/rest/api/3/issue/IS-1")
+	if want := `{"fields":{"components":[{"name":"Web UI"},{"name":"Backend"}]}}`; len(puts) != 1 || puts[0].Body != want {
+		t.Errorf("PUT requests = %+v, want body %s", puts, want)
+	}
+	if out != "Set components of IS-1 to Web UI, Backend\n" {
+		t.Errorf("output = %q", out)
+	}
+
+	captureStdout(t, func() { err = componentCmd(f.config(), []string{"-clear", "IS-1"}) })
+	if puts := f.requests("PUT", "/rest/api/3/issue/IS-1"); err != nil || len(puts) != 2 || puts[1].Body != `{"fields":{"components":[]}}` {
+		t.Errorf("-clear: err = %v, requests = %+v", err, puts)
+	}
+}
+
+func TestResolveComponentsUnknown(t *testing.T) {
+	_, err := resolveComponents([]string{"Backend", "Mobile"}, []Component{{"Backend"}, {"Web UI"}})
+	if !errors.Is(err, ErrUsage) {
+		t.Fatalf("err = %v, want a usage error", err)
+	}
+	if want := `usage error: unknown component "Mobile" (valid: Backend, Web UI)`; err.Error() != want {
+		t.Errorf("err = %q, want %q", err, want)
+	}
+}
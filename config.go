@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// fileConfig is the parsed config file: section name to key/value pairs.
+// Keys before the first [section] header live in the "" section.
+//
+//	# comment
+//	[label_colors]
+//	urgent = red
+type fileConfig map[string]map[string]string
+
+func (c fileConfig) Section(name string) map[string]string {
+	return c[name]
+}
+
+func (c fileConfig) Get(section, key string) string {
+	return c[section][key]
+}
+
+// configPath returns $JIRA_CONFIG, or jira-cli/config under the user's
+// config directory.
+func configPath() string {
+	if p := os.Getenv("JIRA_CONFIG"); p != "" {
+		return p
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "jira-cli", "config")
+}
+
+// loadConfig reads the config file at path. A missing file is not an
+// error and yields an empty config.
+func loadConfig(path string) (fileConfig, error) {
+	if path == "" {
+		return fileConfig{}, nil
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fileConfig{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	c, err := parseConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+// expandValue replaces ${VAR} and $VAR with the environment's values,
+// unset ones with nothing, and $$ with a single $.
+func expandValue(v string) string {
+	return os.Expand(v, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		return os.Getenv(name)
+	})
+}
+
+func parseConfig(r io.Reader) (fileConfig, error) {
+	c := fileConfig{}
+	section := ""
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", n)
+		}
+		k = strings.TrimSpace(k)
+		v = strings.TrimSpace(v)
+		if len(v) >= 2 && v[0] == '"' && v[len(v)-1] == '"' {
+			v = v[1 : len(v)-1]
+		}
+		v = expandValue(v)
+		if c[section] == nil {
+			c[section] = map[string]string{}
+		}
+		c[section][k] = v
+	}
+	return c, sc.Err()
+}
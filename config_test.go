@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpandValue(t *testing.T) {
+	t.Setenv("JIRA_TEST_TOKEN", "s3cret")
+	t.Setenv("JIRA_TEST_EMPTY", "")
+	tests := []struct{ in, want string }{
+		{"${JIRA_TEST_TOKEN}", "s3cret"},
+		{"$JIRA_TEST_TOKEN", "s3cret"},
+		{"prefix-${JIRA_TEST_TOKEN}-suffix", "prefix-s3cret-suffix"},
+		{"${JIRA_TEST_UNSET_VAR}", ""},
+		{"a${JIRA_TEST_EMPTY}b", "ab"},
+		{"pa$$word", "pa$word"},
+		{"$${JIRA_TEST_TOKEN}", "${JIRA_TEST_TOKEN}"},
+		{"plain", "plain"},
+	}
+	for _, tt := range tests {
+		if got := expandValue(tt.in); got != tt.want {
+			t.Errorf("expandValue(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseConfigExpandsValues(t *testing.T) {
+	t.Setenv("JIRA_TEST_TOKEN", "s3cret")
+	c, err := parseConfig(strings.NewReader(`
+# comment
+token = ${JIRA_TEST_TOKEN}
+[headers]
+X-Price = "$$5"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Get("", "token"); got != "s3cret" {
+		t.Errorf("token = %q", got)
+	}
+	if got := c.Get("headers", "X-Price"); got != "$5" {
+		t.Errorf("X-Price = %q", got)
+	}
+	if _, err := parseConfig(strings.NewReader("no equals sign\n")); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("malformed line: err = %v", err)
+	}
+}
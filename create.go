@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type createOptions struct {
+	Project   string
+	Type      string
+	Summary   string
+	AccountID string
+	Labels    []string
+	Priority  string
+	Points    *float64
+
+	// Description is an ADF document, passed through as-is.
+	Description json.RawMessage
+}
+
+// createFields builds the "fields" object for POST /rest/api/3/issue.
+func createFields(o createOptions) map[string]any {
+	fields := map[string]any{
+		"project":   map[string]any{"key": o.Project},
+		"issuetype": map[string]any{"name": o.Type},
+		"summary":   o.Summary,
+	}
+	if o.AccountID != "" {
+		fields["assignee"] = map[string]any{"accountId": o.AccountID}
+	}
+	if len(o.Labels) > 0 {
+		fields["labels"] = o.Labels
+	}
+	if o.Priority != "" {
+		fields["priority"] = map[string]any{"name": o.Priority}
+	}
+	if o.Points != nil {
+		fields["customfield_10004"] = *o.Points
+	}
+	if len(o.Description) > 0 && string(o.Description) != "null" {
+		fields["description"] = o.Description
+	}
+	return fields
+}
+
+// parseLabels splits a comma-separated label list. Jira rejects labels
+// containing whitespace, so those are an error here rather than a 400.
+func parseLabels(s string) ([]string, error) {
+	var labels []string
+	for _, l := range strings.Split(s, ",") {
+		l = strings.TrimSpace(l)
+		if l == "" {
+			continue
+		}
+		if strings.ContainsAny(l, " \t") {
+			return nil, usageError("label %q must not contain spaces", l)
+		}
+		labels = append(labels, l)
+	}
+	return labels, nil
+}
+
+type User struct {
+	AccountID    string `json:"accountId"`
+	EmailAddress string `json:"emailAddress"`
+	DisplayName  string `json:"displayName"`
+	TimeZone     string `json:"timeZone"`
+}
+
+func findAccountID(cfg JiraConfig, email string) (string, error) {
+	var users []User
+	u := apiURL(cfg, url.Values{"query": {email}}, "rest/api/3/user/search")
+	if err := doJSON(cfg, http.MethodGet, u, nil, &users); err != nil {
+		return "", err
+	}
+	for _, us := range users {
+		if strings.EqualFold(us.EmailAddress, email) {
+			return us.AccountID, nil
+		}
+	}
+	// Email addresses are often hidden by privacy settings; accept a
+	// single unambiguous match.
+	if len(users) == 1 {
+		return users[0].AccountID, nil
+	}
+	return "", fmt.Errorf("%w: no unique user matching %q", ErrNotFound, email)
+}
+
+func createIssue(cfg JiraConfig, fields map[string]any) (string, error) {
+	var out struct {
+		Key string `json:"key"`
+	}
+	body := map[string]any{"fields": fields}
+	err := doJSON(cfg, http.MethodPost, apiURL(cfg, nil, "rest/api/3/issue"), body, &out)
+	return out.Key, err
+}
+
+func createCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("create")
+	project := fs.String("project", cfg.DefaultProject, "project key")
+	issueType := fs.String("type", "Task", "issue type")
+	assignee := fs.String("assignee", "", "assignee email")
+	labels := fs.String("labels", "", "comma-separated labels")
+	priority := fs.String("priority", "", "priority name")
+	points := fs.String("points", "", "story points")
+	sprint := fs.String("sprint", "", "add the new issue to this sprint")
+	pos := parseArgs(fs, args)
+
+	o := createOptions{
+		Project:  strings.ToUpper(*project),
+		Type:     *issueType,
+		Summary:  strings.TrimSpace(strings.Join(pos, " ")),
+		Priority: *priority,
+	}
+	if o.Project == "" {
+		return usageError("missing -project (or set JIRA_DEFAULT_PROJECT)")
+	}
+	if o.Summary == "" {
+		return usageError("usage: jira-cli create [-project KEY] [-type TYPE] <summary>")
+	}
+
+	var err error
+	if o.Labels, err = parseLabels(*labels); err != nil {
+		return err
+	}
+	if *points != "" {
+		p, err := strconv.ParseFloat(*points, 64)
+		if err != nil {
+			return usageError("invalid -points %q", *points)
+		}
+		o.Points = &p
+	}
+	if *assignee != "" {
+		if o.AccountID, err = findAccountID(cfg, *assignee); err != nil {
+			return err
+		}
+	}
+
+	var sp *Sprint
+	if *sprint != "" {
+		if sp, err = findSprintByName(cfg, o.Project, *sprint); err != nil {
+			return err
+		}
+	}
+
+	key, err := createIssue(cfg, createFields(o))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Created %s\n", key)
+
+	if sp != nil {
+		if err := addIssueToSprint(cfg, sp.ID, key); err != nil {
+			return err
+		}
+		fmt.Printf("Added %s to %s\n", key, sp.Name)
+	}
+	return nil
+}
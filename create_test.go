@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCreateFields(t *testing.T) {
+	points := 3.0
+	fields := createFields(createOptions{
+		Project:   "PROJ",
+		Type:      "Bug",
+		Summary:   "Login fails",
+		AccountID: "acc-1",
+		Labels:    []string{"ui", "urgent"},
+		Priority:  "High",
+		Points:    &points,
+	})
+	got, err := json.Marshal(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"assignee":{"accountId":"acc-1"},"customfield_10004":3,"issuetype":{"name":"Bug"},` +
+		`"labels":["ui","urgent"],"priority":{"name":"High"},"project":{"key":"PROJ"},"summary":"Login fails"}`
+	if string(got) != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	minimal, _ := json.Marshal(createFields(createOptions{Project: "PROJ", Type: "Task", Summary: "x"}))
+	if want := `{"issuetype":{"name":"Task"},"project":{"key":"PROJ"},"summary":"x"}`; string(minimal) != want {
+		t.Errorf("got  %s\nwant %s", minimal, want)
+	}
+}
+
+func TestParseLabels(t *testing.T) {
+	got, err := parseLabels(" ui, urgent ,,backend")
+	if err != nil || !slices.Equal(got, []string{"ui", "urgent", "backend"}) {
+		t.Errorf("parseLabels = %q, %v", got, err)
+	}
+	if _, err := parseLabels("ui,needs review"); !errors.Is(err, ErrUsage) {
+		t.Errorf("a label with a space: err = %v, want a usage error", err)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+// version is overridden at build time with -ldflags "-X main.version=...".
+var version = "dev"
+
+func cliVersion() string {
+	if version != "dev" {
+		return version
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		return bi.Main.Version
+	}
+	return version
+}
+
+// envVars are the environment variables jira-cli reads, and whether their
+// values must be kept out of debug output.
+var envVars = []struct {
+	Name   string
+	Secret bool
+}{
+	{"JIRA_EMAIL", false},
+	{"JIRA_URL", false},
+	{"JIRA_API_TOKEN", true},
+	{"JIRA_DEFAULT_PROJECT", false},
+	{"JIRA_ASSIGNEE_CLAUSE", false},
+	{"JIRA_SEARCH_API", false},
+	{"JIRA_PAGE_SIZE", false},
+	{"JIRA_RATE_LIMIT", false},
+	{"JIRA_CACHE_TTL", false},
+	{"JIRA_THEME", false},
+	{"JIRA_EPIC_LINK_FIELD", false},
+	{"JIRA_CONFIG", false},
+	{"NO_COLOR", false},
+	{"EDITOR", false},
+}
+
+func redact(v string) string {
+	if v == "" {
+		return "(empty)"
+	}
+	return "<redacted>"
+}
+
+// writeDebugInfo prints what a bug report needs about the local setup.
+// Secret values are never printed, and of the config file only section
+// and key names are shown.
+func writeDebugInfo(w io.Writer, cfg JiraConfig) {
+	row := func(k, v string) { fmt.Fprintf(w, "%-22s %s\n", k, v) }
+	row("version", cliVersion())
+	row("go", runtime.Version()+" "+runtime.GOOS+"/"+runtime.GOARCH)
+	row("url", cfg.URL)
+	row("email", cfg.Email)
+	row("token", redact(cfg.Token))
+	row("project", orDash(cfg.DefaultProject))
+	row("search api", cfg.SearchPath)
+	row("page size", fmt.Sprint(clampPageSize(cfg.PageSize)))
+	row("timeout", httpClient.Timeout.String())
+	row("locale", "decimal separator "+decimalSep)
+
+	path := configPath()
+	if _, err := os.Stat(path); err != nil {
+		path += " (not found)"
+	}
+	row("config", path)
+	sections := make([]string, 0, len(cfg.File))
+	for s := range cfg.File {
+		sections = append(sections, s)
+	}
+	sort.Strings(sections)
+	for _, s := range sections {
+		keys := make([]string, 0, len(cfg.File[s]))
+		for k := range cfg.File[s] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		row("  ["+s+"]", strings.Join(keys, ", "))
+	}
+
+	fmt.Fprintln(w, "environment:")
+	for _, e := range envVars {
+		v, ok := os.LookupEnv(e.Name)
+		switch {
+		case !ok:
+			v = "(unset)"
+		case e.Secret:
+			v = redact(v)
+		}
+		row("  "+e.Name, v)
+	}
+}
+
+func debugInfoCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("debug-info")
+	if pos := parseArgs(fs, args); len(pos) > 0 {
+		return usageError("usage: jira-cli debug-info")
+	}
+	writeDebugInfo(os.Stdout, cfg)
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDebugInfoHasNoSecrets(t *testing.T) {
+	t.Setenv("JIRA_API_TOKEN", "env-token-secret")
+	t.Setenv("JIRA_CONFIG", filepath.Join(t.TempDir(), "config"))
+	cfg := JiraConfig{
+		Email: "me@example.com",
+		Token: "cfg-token-secret",
+		URL:   "https://example.atlassian.net",
+		File: fileConfig{
+			"":        {"token": "file-token-secret"},
+			"headers": {"X-Api-Key": "header-secret"},
+		},
+	}
+
+	var b strings.Builder
+	writeDebugInfo(&b, cfg)
+	out := b.String()
+	for _, secret := range []string{"env-token-secret", "cfg-token-secret", "file-token-secret", "header-secret"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("debug info contains %q:\n%s", secret, out)
+		}
+	}
+	for _, want := range []string{"https://example.atlassian.net", "<redacted>", "X-Api-Key"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("debug info lacks %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// cacheDir returns the per-user cache directory for jira-cli.
+func cacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "jira-cli"), nil
+}
+
+// snapshot maps issue keys to their status at the time of a listing.
+type snapshot map[string]string
+
+func newSnapshot(issues []JiraIssue) snapshot {
+	s := snapshot{}
+	for _, ji := range issues {
+		s[ji.Key] = ji.Fields.Status.Name
+	}
+	return s
+}
+
+// snapshotPath keeps one snapshot per query so -team or -jql runs don't
+// show up as changes to the default listing.
+func snapshotPath(jql string) (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha1.Sum([]byte(jql))
+	return filepath.Join(dir, "snapshot-"+hex.EncodeToString(sum[:4])+".json"), nil
+}
+
+// loadSnapshot returns nil, without error, when no snapshot exists yet.
+func loadSnapshot(path string) (snapshot, error) {
+	buf, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s snapshot
+	if err := json.Unmarshal(buf, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+func saveSnapshot(path string, s snapshot) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	buf, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf, 0o644)
+}
+
+type deltaKind string
+
+const (
+	deltaNew     deltaKind = "NEW"
+	deltaChanged deltaKind = "CHANGED"
+	deltaGone    deltaKind = "GONE"
+)
+
+type deltaEntry struct {
+	Kind    deltaKind
+	Key     string
+	From    string
+	To      string
+	Summary string
+}
+
+// diffSnapshot classifies issues against the previous snapshot. Entries
+// are ordered by key.
+func diffSnapshot(prev snapshot, issues []JiraIssue) []deltaEntry {
+	var out []deltaEntry
+	seen := map[string]bool{}
+	for _, ji := range issues {
+		seen[ji.Key] = true
+		status := ji.Fields.Status.Name
+		old, ok := prev[ji.Key]
+		switch {
+		case !ok:
+			out = append(out, deltaEntry{deltaNew, ji.Key, "", status, ji.Fields.Summary})
+		case old != status:
+			out = append(out, deltaEntry{deltaChanged, ji.Key, old, status, ji.Fields.Summary})
+		}
+	}
+	for key, old := range prev {
+		if !seen[key] {
+			out = append(out, deltaEntry{deltaGone, key, old, "", ""})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return keyLess(out[i].Key, out[j].Key) })
+	return out
+}
+
+func formatDelta(entries []deltaEntry) string {
+	if len(entries) == 0 {
+		return "No changes since last run"
+	}
+	var lines []string
+	for _, e := range entries {
+		var status string
+		switch e.Kind {
+		case deltaNew:
+			status = e.To
+		case deltaChanged:
+			status = e.From + " → " + e.To
+		case deltaGone:
+			status = e.From
+		}
+		lines = append(lines, strings.TrimRight(fmt.Sprintf("%-8s %s\t%s\t%s", e.Kind, e.Key, status, e.Summary), "\t"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// deltaFlow prints what changed since the previous -delta run for the
+// same query and records the current listing for next time.
+func deltaFlow(jql string, issues []JiraIssue) error {
+	path, err := snapshotPath(jql)
+	if err != nil {
+		return err
+	}
+	prev, err := loadSnapshot(path)
+	if err != nil {
+		return err
+	}
+	if err := saveSnapshot(path, newSnapshot(issues)); err != nil {
+		return err
+	}
+	if prev == nil {
+		fmt.Printf("Saved snapshot of %d issues; run again to see changes\n", len(issues))
+		return nil
+	}
+	fmt.Println(formatDelta(diffSnapshot(prev, issues)))
+	return nil
+}
+
+func resetDelta(jql string) error {
+	path, err := snapshotPath(jql)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	fmt.Println("Snapshot reset")
+	return nil
+}
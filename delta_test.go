@@ -0,0 +1,58 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffSnapshot(t *testing.T) {
+	prev := newSnapshot([]JiraIssue{
+		sampleIssue("IS-1", "Open", 1),
+		sampleIssue("IS-2", "Open", 1),
+		sampleIssue("IS-10", "In Review", 1),
+	})
+	next := []JiraIssue{
+		sampleIssue("IS-1", "Open", 1),
+		sampleIssue("IS-2", "In Progress", 1),
+		sampleIssue("IS-3", "Open", 1),
+	}
+
+	got := diffSnapshot(prev, next)
+	want := []deltaEntry{
+		{deltaChanged, "IS-2", "Open", "In Progress", "Summary of IS-2"},
+		{deltaNew, "IS-3", "", "Open", "Summary of IS-3"},
+		{deltaGone, "IS-10", "In Review", "", ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+	if out := formatDelta(got); !strings.Contains(out, "CHANGED  IS-2\tOpen → In Progress\tSummary of IS-2") {
+		t.Errorf("formatDelta:\n%s", out)
+	}
+	if got := diffSnapshot(newSnapshot(next), next); len(got) != 0 {
+		t.Errorf("unchanged listing: %+v", got)
+	}
+}
+
+func TestDeltaFlowSnapshots(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	out := captureStdout(t, func() {
+		if err := deltaFlow("q", []JiraIssue{sampleIssue("IS-1", "Open", 1)}); err != nil {
+			t.Error(err)
+		}
+	})
+	if !strings.HasPrefix(out, "Saved snapshot of 1 issues") {
+		t.Errorf("first run: %q", out)
+	}
+	out = captureStdout(t, func() {
+		if err := deltaFlow("q", []JiraIssue{sampleIssue("IS-1", "Done", 1)}); err != nil {
+			t.Error(err)
+		}
+	})
+	if !strings.HasPrefix(out, "CHANGED  IS-1\tOpen → Done") {
+		t.Errorf("second run: %q", out)
+	}
+}
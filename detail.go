@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+func getIssue(cfg JiraConfig, issueKey string, fields ...string) (JiraIssue, error) {
+	var ji JiraIssue
+	var q url.Values
+	if len(fields) > 0 {
+		q = url.Values{"fields": {strings.Join(fields, ",")}}
+	}
+	err := doJSON(cfg, http.MethodGet, apiURL(cfg, q, "rest/api/3/issue", url.PathEscape(issueKey)), nil, &ji)
+	return ji, err
+}
+
+var detailFields = []string{"summary", "issuetype", "status", "assignee", "project", "components", "customfield_10004", "customfield_10007", "subtasks"}
+
+func formatSubtasks(subtasks []JiraIssue) []string {
+	var lines []string
+	for _, st := range subtasks {
+		lines = append(lines, fmt.Sprintf("  %s\t[%s]\t%s", st.Key, st.Fields.Status.Name, st.Fields.Summary))
+	}
+	return lines
+}
+
+// formatDetail renders ji; epicNames names a classic epic link, as
+// with the listing's epic column.
+func formatDetail(ji JiraIssue, epicNames map[string]string) string {
+	f := ji.Fields
+	lines := []string{
+		ji.Key + "  " + f.Summary,
+		"Type:     " + f.IssueType.Name,
+		"Status:   " + f.Status.Name,
+		"Assignee: " + assigneeName(ji),
+		"Points:   " + formatPoints(f.Points),
+		"Sprint:   " + sprintName(f.Sprints),
+	}
+	if f.IssueType.Subtask && f.Parent != nil {
+		lines = append(lines, "Parent:   "+f.Parent.Key+" "+f.Parent.Fields.Summary)
+	}
+	if key, _ := epicOf(ji); key != "" {
+		lines = append(lines, "Epic:     "+strings.TrimPrefix(formatEpic(ji, formatOptions{EpicNames: epicNames}), epicMark))
+	}
+	if len(f.Components) > 0 {
+		lines = append(lines, "Components: "+componentNames(f.Components))
+	}
+	if len(f.Subtasks) > 0 {
+		lines = append(lines, "Subtasks:")
+		lines = append(lines, formatSubtasks(f.Subtasks)...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func detailCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("detail")
+	pos := parseArgs(fs, args)
+	if len(pos) != 1 {
+		return usageError("usage: jira-cli detail <KEY>")
+	}
+
+	wantEpics(cfg)
+	ji, err := getIssue(cfg, expandKey(cfg, pos[0]), detailFields...)
+	if err != nil {
+		return err
+	}
+	fmt.Println(formatDetail(ji, epicNames(cfg, []JiraIssue{ji})))
+	return nil
+}
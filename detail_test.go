@@ -0,0 +1,20 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatDetailSubtasks(t *testing.T) {
+	parent := sampleIssue("IS-1", "In Progress", 5)
+	parent.Fields.Subtasks = []JiraIssue{sampleIssue("IS-2", "Open", 0), sampleIssue("IS-3", "Done", 0)}
+
+	out := formatDetail(parent, nil)
+	want := "Subtasks:\n  IS-2\t[Open]\tSummary of IS-2\n  IS-3\t[Done]\tSummary of IS-3"
+	if !strings.HasSuffix(out, want) {
+		t.Errorf("got:\n%s\nwant it to end with:\n%s", out, want)
+	}
+	if out := formatDetail(sampleIssue("IS-4", "Open", 1), nil); strings.Contains(out, "Subtasks:") {
+		t.Errorf("issue without subtasks has a Subtasks section:\n%s", out)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// runEditor opens path in $EDITOR (default vi) and waits for it to exit.
+var runEditor = func(path string) error {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
+
+var errEditAborted = errors.New("edit aborted")
+
+// editText writes text to a temp file, runs the editor on it and returns
+// the saved contents.
+func editText(text string) (string, error) {
+	f, err := os.CreateTemp("", "jira-cli-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	defer atInterrupt(func() { os.Remove(f.Name()) })()
+	if _, err := f.WriteString(text); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	if err := withTerminalChild(func() error { return runEditor(f.Name()) }); err != nil {
+		return "", fmt.Errorf("%w: editor: %v", errEditAborted, err)
+	}
+	buf, err := os.ReadFile(f.Name())
+	return string(buf), err
+}
+
+// editDocument is what the user edits: the summary on the first line,
+// then optionally a blank line and the description.
+func editDocument(summary, description string, withDescription bool) string {
+	if !withDescription {
+		return summary + "\n"
+	}
+	return summary + "\n\n" + description + "\n"
+}
+
+func parseEditDocument(doc string) (summary, description string) {
+	summary, description, _ = strings.Cut(doc, "\n")
+	return strings.TrimSpace(summary), strings.TrimSpace(description)
+}
+
+// changedFields returns the PUT fields for whatever differs. The old
+// values are trimmed as parseEditDocument trims the new ones, so trailing
+// blank lines or a final hard break don't count as an edit.
+func changedFields(oldSummary, newSummary, oldDesc, newDesc string, withDescription bool) map[string]any {
+	fields := map[string]any{}
+	if newSummary != strings.TrimSpace(oldSummary) {
+		fields["summary"] = newSummary
+	}
+	if withDescription && newDesc != strings.TrimSpace(oldDesc) {
+		fields["description"] = textToADF(newDesc)
+	}
+	return fields
+}
+
+func editCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("edit")
+	withDesc := fs.Bool("description", false, "also edit the description (as plain text; rich formatting is not kept)")
+	pos := parseArgs(fs, args)
+	if len(pos) != 1 {
+		return usageError("usage: jira-cli edit <KEY> [-description]")
+	}
+	key := expandKey(cfg, pos[0])
+
+	var issue struct {
+		Fields struct {
+			Summary     string          `json:"summary"`
+			Description json.RawMessage `json:"description"`
+		} `json:"fields"`
+	}
+	u := apiURL(cfg, url.Values{"fields": {"summary,description"}}, "rest/api/3/issue", url.PathEscape(key))
+	if err := doJSON(cfg, http.MethodGet, u, nil, &issue); err != nil {
+		return err
+	}
+	oldSummary := issue.Fields.Summary
+	oldDesc := adfToText(issue.Fields.Description)
+
+	edited, err := editText(editDocument(oldSummary, oldDesc, *withDesc))
+	if err != nil {
+		return err
+	}
+	newSummary, newDesc := parseEditDocument(edited)
+	if newSummary == "" {
+		return usageError("summary cannot be empty")
+	}
+
+	fields := changedFields(oldSummary, newSummary, oldDesc, newDesc, *withDesc)
+	if len(fields) == 0 {
+		fmt.Println("No changes")
+		return nil
+	}
+
+	u = apiURL(cfg, nil, "rest/api/3/issue", url.PathEscape(key))
+	if err := doJSON(cfg, http.MethodPut, u, map[string]any{"fields": fields}, nil); err != nil {
+		return err
+	}
+	fmt.Printf("Updated %s\n", key)
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// fakeEditor makes runEditor apply edit to the document being edited.
+func fakeEditor(t *testing.T, edit func(doc string) string) {
+	t.Helper()
+	setGlobal(t, &runEditor, func(path string) error {
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, []byte(edit(string(buf))), 0o600)
+	})
+}
+
+func TestEditSendsOnlyChangedFields(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("GET /rest/api/3/issue/IS-1", 200, map[string]any{"fields": map[string]any{
+		"summary":     "Old title",
+		"description": textToADF("Keep this"),
+	}})
+	f.reply("PUT /rest/api/3/issue/IS-1", 204, nil)
+	fakeEditor(t, func(doc string) string { return strings.Replace(doc, "Old title", "New title", 1) })
+
+	var err error
+	out := captureStdout(t, func() { err = editCmd(f.config(), []string{"-description", "IS-1"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	puts := f.requests("PUT", "/rest/api/3/issue/IS-1")
+	if len(puts) != 1 {
+		t.Fatalf("%d PUT requests, want 1", len(puts))
+	}
+	if want := `{"fields":{"summary":"New title"}}`; puts[0].Body != want {
+		t.Errorf("PUT body = %s, want %s", puts[0].Body, want)
+	}
+	if out != "Updated IS-1\n" {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestEditIgnoresTrimmedWhitespace(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("GET /rest/api/3/issue/IS-1", 200, map[string]any{"fields": map[string]any{
+		"summary":     "Title with a trailing space ",
+		"description": textToADF("Body\n\n"),
+	}})
+	fakeEditor(t, func(doc string) string { return doc })
+
+	var err error
+	out := captureStdout(t, func() { err = editCmd(f.config(), []string{"-description", "IS-1"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "No changes\n" {
+		t.Errorf("output = %q, want No changes", out)
+	}
+	if n := len(f.requests("PUT", "/rest/api/3/issue/IS-1")); n != 0 {
+		t.Errorf("%d PUT requests for an unchanged issue", n)
+	}
+}
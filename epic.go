@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ParentIssue is the parent field: the epic of a standard issue, where
+// Cloud links epics through the hierarchy, or the issue a subtask
+// belongs to.
+type ParentIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary   string `json:"summary"`
+		IssueType struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+	} `json:"fields"`
+}
+
+// defaultEpicLinkField is the usual ID of the classic Epic Link field;
+// JIRA_EPIC_LINK_FIELD overrides it where the instance differs.
+const defaultEpicLinkField = "customfield_10014"
+
+// epicMark prefixes the epic column, e.g. "↳ EPIC-12 Checkout".
+const epicMark = "↳ "
+
+// wantEpics adds the fields the epic column reads to what searches
+// and detail request.
+func wantEpics(cfg JiraConfig) {
+	searchFields = append(searchFields, "parent", cfg.EpicLinkField)
+	detailFields = append(detailFields, "parent", cfg.EpicLinkField)
+	rawFields = append(rawFields, cfg.EpicLinkField)
+	epicLinkField = cfg.EpicLinkField
+}
+
+// epicLinkField is the field wantEpics asked for, read by epicOf.
+var epicLinkField string
+
+// epicOf returns the key of ji's epic, and its name when the response
+// carried one. A standard issue's parent is its epic; a subtask's
+// parent is its story, so subtasks only go by the classic epic link,
+// which names the key alone.
+func epicOf(ji JiraIssue) (key, name string) {
+	if p := ji.Fields.Parent; p != nil && !ji.Fields.IssueType.Subtask {
+		return p.Key, p.Fields.Summary
+	}
+	var link string
+	if raw := ji.Fields.Custom[epicLinkField]; raw != nil && json.Unmarshal(raw, &link) == nil {
+		return link, ""
+	}
+	return "", ""
+}
+
+// epicNames looks up the names of classic epic links, which carry only
+// a key. The names are cosmetic, so a failed lookup leaves them out.
+func epicNames(cfg JiraConfig, issues []JiraIssue) map[string]string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, ji := range issues {
+		key, name := epicOf(ji)
+		if key != "" && name == "" && !seen[key] && findIssueKey(key) == key {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	epics, err := searchIssues(cfg, "key in ("+strings.Join(keys, ", ")+")")
+	if err != nil {
+		debugf("looking up epic names: %v", err)
+		return nil
+	}
+	names := map[string]string{}
+	for _, e := range epics {
+		names[e.Key] = e.Fields.Summary
+	}
+	return names
+}
+
+func formatEpic(ji JiraIssue, opts formatOptions) string {
+	key, name := epicOf(ji)
+	if key == "" {
+		return "-"
+	}
+	if name == "" {
+		name = opts.EpicNames[key]
+	}
+	return strings.TrimSpace(epicMark + key + " " + name)
+}
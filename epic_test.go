@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEpicOf(t *testing.T) {
+	setGlobal(t, &epicLinkField, defaultEpicLinkField)
+
+	story := sampleIssue("IS-1", "Open", 0)
+	story.Fields.Parent = &ParentIssue{Key: "EPIC-12"}
+	story.Fields.Parent.Fields.Summary = "Checkout"
+
+	classic := sampleIssue("IS-2", "Open", 0)
+	classic.Fields.Custom = map[string]json.RawMessage{defaultEpicLinkField: json.RawMessage(`"EPIC-7"`)}
+
+	// A subtask's parent is its story, not its epic.
+	subtask := sampleIssue("IS-3", "Open", 0)
+	subtask.Fields.IssueType.Subtask = true
+	subtask.Fields.Parent = &ParentIssue{Key: "IS-1"}
+
+	tests := []struct {
+		ji        JiraIssue
+		key, name string
+	}{
+		{story, "EPIC-12", "Checkout"},
+		{classic, "EPIC-7", ""},
+		{subtask, "", ""},
+		{sampleIssue("IS-4", "Open", 0), "", ""},
+	}
+	for _, tt := range tests {
+		if key, name := epicOf(tt.ji); key != tt.key || name != tt.name {
+			t.Errorf("epicOf(%s) = %q, %q; want %q, %q", tt.ji.Key, key, name, tt.key, tt.name)
+		}
+	}
+}
+
+func TestFormatEpic(t *testing.T) {
+	setGlobal(t, &epicLinkField, defaultEpicLinkField)
+
+	story := sampleIssue("IS-1", "Open", 0)
+	story.Fields.Parent = &ParentIssue{Key: "EPIC-12"}
+	story.Fields.Parent.Fields.Summary = "Checkout"
+	classic := sampleIssue("IS-2", "Open", 0)
+	classic.Fields.Custom = map[string]json.RawMessage{defaultEpicLinkField: json.RawMessage(`"EPIC-7"`)}
+
+	opts := formatOptions{EpicNames: map[string]string{"EPIC-7": "Search"}}
+	for ji, want := range map[*JiraIssue]string{
+		&story:   "↳ EPIC-12 Checkout",
+		&classic: "↳ EPIC-7 Search",
+	} {
+		if got := formatEpic(*ji, opts); got != want {
+			t.Errorf("formatEpic(%s) = %q, want %q", ji.Key, got, want)
+		}
+	}
+	if got := formatEpic(classic, formatOptions{}); got != "↳ EPIC-7" {
+		t.Errorf("formatEpic without a looked-up name = %q", got)
+	}
+	if got := formatEpic(sampleIssue("IS-3", "Open", 0), opts); got != "-" {
+		t.Errorf("formatEpic without an epic = %q, want -", got)
+	}
+}
+
+func TestEpicNamesLooksUpClassicLinks(t *testing.T) {
+	setGlobal(t, &epicLinkField, defaultEpicLinkField)
+	f := newFakeJira(t)
+	epic := sampleIssue("EPIC-7", "Open", 0)
+	epic.Fields.Summary = "Search"
+	f.reply("POST /rest/api/3/search/jql", 200, searchResult(epic))
+
+	classic := sampleIssue("IS-2", "Open", 0)
+	classic.Fields.Custom = map[string]json.RawMessage{defaultEpicLinkField: json.RawMessage(`"EPIC-7"`)}
+	names := epicNames(f.config(), []JiraIssue{classic, classic})
+	if names["EPIC-7"] != "Search" {
+		t.Errorf("epicNames = %v", names)
+	}
+	if n := len(f.requests("POST", "/rest/api/3/search/jql")); n != 1 {
+		t.Errorf("%d searches, want 1", n)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+)
+
+var (
+	ErrAuth       = errors.New("authentication failed")
+	ErrPermission = errors.New("permission denied")
+	ErrNotFound   = errors.New("not found")
+	ErrNetwork    = errors.New("network error")
+	ErrRateLimit  = errors.New("rate limited")
+	ErrUsage      = errors.New("usage error")
+)
+
+// APIError is a non-2xx response from Jira. It unwraps to one of the
+// sentinel errors above when the status code maps to one.
+type APIError struct {
+	StatusCode int
+	Status     string
+
+	// Hint, when set, tells the user what to do about the error.
+	Hint string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("jira error: %d %s", e.StatusCode, e.Status)
+	if e.Hint != "" {
+		msg += ": " + e.Hint
+	}
+	return msg
+}
+
+func (e *APIError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return ErrAuth
+	case http.StatusForbidden:
+		return ErrPermission
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimit
+	}
+	return nil
+}
+
+// apiTokensURL is where Atlassian Cloud users manage their API tokens.
+const apiTokensURL = "https://id.atlassian.com/manage-profile/security/api-tokens"
+
+// statusHint explains the responses users can act on. A 401 means the
+// credentials themselves were refused, most often an expired or revoked
+// token; a 403 means they were accepted but lack a permission.
+func statusHint(res *http.Response) string {
+	switch res.StatusCode {
+	case http.StatusUnauthorized:
+		hint := "the credentials were rejected; check JIRA_EMAIL, and if the API token expired or was revoked, create a new one"
+		if res.Request != nil && strings.HasSuffix(res.Request.URL.Hostname(), ".atlassian.net") {
+			hint += " at " + apiTokensURL
+		}
+		return hint + " and update JIRA_API_TOKEN"
+	case http.StatusForbidden:
+		return "insufficient permissions: the account signed in, but is not allowed to do this"
+	}
+	return ""
+}
+
+func usageError(format string, a ...any) error {
+	return fmt.Errorf("%w: %s", ErrUsage, fmt.Sprintf(format, a...))
+}
+
+// classifyError maps err to a short type name and the process exit code.
+func classifyError(err error) (string, int) {
+	var apiErr *APIError
+	switch {
+	case errors.Is(err, ErrUsage):
+		return "usage", 2
+	case errors.Is(err, ErrAuth), errors.Is(err, ErrPermission):
+		return "auth", 3
+	case errors.Is(err, ErrNotFound):
+		return "notfound", 4
+	case errors.Is(err, ErrNetwork):
+		return "network", 5
+	case errors.Is(err, ErrRateLimit):
+		return "ratelimit", 6
+	case errors.As(err, &apiErr) && apiErr.StatusCode >= 500:
+		return "server", 7
+	}
+	return "error", 1
+}
+
+// errorFormat selects how fail reports errors: "text" or "json".
+var errorFormat = "text"
+
+func formatErrorJSON(err error) []byte {
+	typ, code := classifyError(err)
+	buf, _ := json.Marshal(struct {
+		Error    string `json:"error"`
+		Type     string `json:"type"`
+		ExitCode int    `json:"exit_code"`
+	}{err.Error(), typ, code})
+	return buf
+}
+
+// fail reports err on stderr and exits with its mapped code.
+func fail(err error) {
+	// A request cut short by Ctrl-C is not an error to report.
+	if errors.Is(err, context.Canceled) {
+		exitInterrupted()
+	}
+	_, code := classifyError(err)
+	if errorFormat == "json" {
+		os.Stderr.Write(append(formatErrorJSON(err), '\n'))
+	} else {
+		log.Print(err)
+	}
+	printRetrySummary()
+	os.Exit(code)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestFormatErrorJSON(t *testing.T) {
+	tests := []struct {
+		err  error
+		typ  string
+		code int
+	}{
+		{usageError("bad flag"), "usage", 2},
+		{&APIError{StatusCode: 401, Status: "401 Unauthorized"}, "auth", 3},
+		{fmt.Errorf("IS-1: %w", &APIError{StatusCode: 404, Status: "404 Not Found"}), "notfound", 4},
+		{fmt.Errorf("%w: dial tcp", ErrNetwork), "network", 5},
+		{&APIError{StatusCode: 429, Status: "429 Too Many Requests"}, "ratelimit", 6},
+		{&APIError{StatusCode: 502, Status: "502 Bad Gateway"}, "server", 7},
+		{errors.New("something else"), "error", 1},
+	}
+	for _, tt := range tests {
+		var got struct {
+			Error    string `json:"error"`
+			Type     string `json:"type"`
+			ExitCode int    `json:"exit_code"`
+		}
+		if err := json.Unmarshal(formatErrorJSON(tt.err), &got); err != nil {
+			t.Fatalf("%v: %v", tt.err, err)
+		}
+		if got.Error != tt.err.Error() || got.Type != tt.typ || got.ExitCode != tt.code {
+			t.Errorf("%v: got %+v, want type %s and exit code %d", tt.err, got, tt.typ, tt.code)
+		}
+	}
+}
+
+func TestPickFromListInvalidSelectionIsUsageError(t *testing.T) {
+	answerPrompts(t, "9")
+	var err error
+	captureStdout(t, func() { _, err = pickFromList("Select", []string{"a", "b"}) })
+	if !errors.Is(err, ErrUsage) {
+		t.Fatalf("err = %v, want a usage error", err)
+	}
+	if _, code := classifyError(err); code != 2 {
+		t.Errorf("exit code %d, want 2", code)
+	}
+}
+
+func TestStatusHints(t *testing.T) {
+	for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden} {
+		f := newFakeJira(t)
+		f.reply("GET /rest/api/3/myself", status, map[string]any{"errorMessages": []string{"nope"}})
+		_, err := getMyself(f.config())
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("%d: err = %v, want an APIError", status, err)
+		}
+		switch status {
+		case http.StatusUnauthorized:
+			if !errors.Is(err, ErrAuth) || !strings.Contains(err.Error(), "create a new one") || !strings.Contains(err.Error(), "JIRA_API_TOKEN") {
+				t.Errorf("401: %v, want a hint to regenerate the token", err)
+			}
+			if strings.Contains(err.Error(), apiTokensURL) {
+				t.Errorf("401 from a non-Cloud host links the Cloud token page: %v", err)
+			}
+		case http.StatusForbidden:
+			if !errors.Is(err, ErrPermission) || !strings.Contains(err.Error(), "insufficient permissions") || strings.Contains(err.Error(), "token") {
+				t.Errorf("403: %v, want an insufficient-permissions hint", err)
+			}
+		}
+	}
+}
+
+func TestStatusHintLinksCloudTokens(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.atlassian.net/rest/api/3/myself", nil)
+	hint := statusHint(&http.Response{StatusCode: http.StatusUnauthorized, Request: req})
+	if !strings.Contains(hint, apiTokensURL) {
+		t.Errorf("Cloud 401 hint %q does not link %s", hint, apiTokensURL)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// mappedField is one -field-map entry: a field ID shown as a column
+// under a friendlier name.
+type mappedField struct {
+	ID     string
+	Header string
+}
+
+// rawFields are the field IDs whose raw values IssueFields keeps in
+// Custom: the -field-map fields and the epic link field.
+var rawFields []string
+
+// parseFieldMap parses "customfield_10050=Team,customfield_10060=QA".
+func parseFieldMap(spec string) ([]mappedField, error) {
+	var out []mappedField
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, header, ok := strings.Cut(entry, "=")
+		id, header = strings.TrimSpace(id), strings.TrimSpace(header)
+		if !ok || id == "" || header == "" {
+			return nil, usageError("invalid -field-map entry %q (want FIELD=Header)", entry)
+		}
+		if lookupColumn(header) != nil {
+			return nil, usageError("-field-map header %q is already a column", header)
+		}
+		for _, f := range out {
+			if strings.EqualFold(f.Header, header) {
+				return nil, usageError("-field-map header %q is used twice", header)
+			}
+		}
+		out = append(out, mappedField{ID: id, Header: header})
+	}
+	if len(out) == 0 {
+		return nil, usageError("-field-map must map at least one field")
+	}
+	return out, nil
+}
+
+// registerFieldMap makes each mapped field a column and adds it to the
+// fields searches request.
+func registerFieldMap(fields []mappedField) {
+	for _, f := range fields {
+		columns = append(columns, column{f.Header, func(ji JiraIssue, _ formatOptions) string {
+			return orDash(customFieldText(ji.Fields.Custom[f.ID]))
+		}})
+		searchFields = append(searchFields, f.ID)
+		rawFields = append(rawFields, f.ID)
+	}
+}
+
+func (f *IssueFields) UnmarshalJSON(b []byte) error {
+	type plain IssueFields
+	if err := json.Unmarshal(b, (*plain)(f)); err != nil {
+		return err
+	}
+	if len(rawFields) == 0 {
+		return nil
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	for _, id := range rawFields {
+		if v, ok := raw[id]; ok {
+			if f.Custom == nil {
+				f.Custom = map[string]json.RawMessage{}
+			}
+			f.Custom[id] = v
+		}
+	}
+	return nil
+}
+
+// customFieldText renders a field value of unknown shape: scalars as
+// themselves, option and user objects by their value, name or display
+// name, and lists joined with commas.
+func customFieldText(raw json.RawMessage) string {
+	var v any
+	if len(raw) == 0 || json.Unmarshal(raw, &v) != nil {
+		return ""
+	}
+	return anyText(v)
+}
+
+func anyText(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, e := range v {
+			if s := anyText(e); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case map[string]any:
+		for _, k := range []string{"value", "name", "displayName"} {
+			if s, ok := v[k].(string); ok {
+				return s
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestFieldMapColumns(t *testing.T) {
+	setGlobal(t, &columns, slices.Clone(columns))
+	setGlobal(t, &searchFields, slices.Clone(searchFields))
+	setGlobal(t, &rawFields, slices.Clone(rawFields))
+
+	m, err := parseFieldMap("customfield_10050=Team, customfield_10060=QA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registerFieldMap(m)
+	if !slices.Contains(searchFields, "customfield_10050") || !slices.Contains(searchFields, "customfield_10060") {
+		t.Errorf("searchFields = %v lack the mapped fields", searchFields)
+	}
+
+	var issues []JiraIssue
+	err = json.Unmarshal([]byte(`[
+		{"key": "IS-1", "fields": {"customfield_10050": "Payments", "customfield_10060": {"id": "3", "value": "Passed"}}},
+		{"key": "IS-2", "fields": {"customfield_10050": null}}
+	]`), &issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := formatFlat(issues, formatOptions{Columns: []string{"key", "Team", "QA"}})
+	if want := "KEY\tTEAM\tQA\nIS-1\tPayments\tPassed\nIS-2\t-\t-"; out != want {
+		t.Errorf("got:\n%q\nwant:\n%q", out, want)
+	}
+}
+
+func TestParseFieldMapInvalid(t *testing.T) {
+	for _, spec := range []string{"", "customfield_1", "=Team", "customfield_1=", "customfield_1=status", "cf_1=Team,cf_2=team"} {
+		if _, err := parseFieldMap(spec); !errors.Is(err, ErrUsage) {
+			t.Errorf("parseFieldMap(%q): err = %v, want a usage error", spec, err)
+		}
+	}
+}
+
+func TestCustomFieldText(t *testing.T) {
+	tests := map[string]string{
+		`"text"`:                         "text",
+		`3.5`:                            "3.5",
+		`true`:                           "true",
+		`{"value": "Gold"}`:              "Gold",
+		`{"displayName": "Ada"}`:         "Ada",
+		`[{"name": "a"}, {"name": "b"}]`: "a, b",
+		`null`:                           "",
+	}
+	for raw, want := range tests {
+		if got := customFieldText(json.RawMessage(raw)); got != want {
+			t.Errorf("customFieldText(%s) = %q, want %q", raw, got, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+// previewWidth caps the value column of the fields command.
+const previewWidth = 60
+
+type fieldInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func getFieldNames(cfg JiraConfig) (map[string]string, error) {
+	var fields []fieldInfo
+	if err := doJSON(cfg, http.MethodGet, apiURL(cfg, nil, "rest/api/3/field"), nil, &fields); err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(fields))
+	for _, f := range fields {
+		names[f.ID] = f.Name
+	}
+	return names, nil
+}
+
+// previewValue shortens a field value to one line: readable text where
+// customFieldText or, for rich text, adfToText finds some, otherwise the
+// compact JSON.
+func previewValue(raw json.RawMessage) string {
+	s := customFieldText(raw)
+	var doc struct{ Type string }
+	if s == "" && json.Unmarshal(raw, &doc) == nil && doc.Type == "doc" {
+		s = adfToText(raw)
+	}
+	if s == "" {
+		var b bytes.Buffer
+		if json.Compact(&b, raw) == nil {
+			s = b.String()
+		}
+	}
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) > previewWidth {
+		s = string([]rune(s)[:previewWidth-1]) + "…"
+	}
+	return s
+}
+
+func isEmptyValue(raw json.RawMessage) bool {
+	switch string(bytes.TrimSpace(raw)) {
+	case "", "null", "[]", "{}", `""`:
+		return true
+	}
+	return false
+}
+
+// writeFields lists the issue's fields sorted by ID, with their names
+// and a preview of each value. Empty ones are left out unless all.
+func writeFields(w io.Writer, fields map[string]json.RawMessage, names map[string]string, all bool) error {
+	ids := make([]string, 0, len(fields))
+	for id, v := range fields {
+		if all || !isEmptyValue(v) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tVALUE")
+	for _, id := range ids {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", id, orDash(names[id]), previewValue(fields[id]))
+	}
+	return tw.Flush()
+}
+
+func fieldsCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("fields")
+	all := fs.Bool("all", false, "include fields with no value")
+	pos := parseArgs(fs, args)
+	if len(pos) != 1 {
+		return usageError("usage: jira-cli fields <KEY> [-all]")
+	}
+
+	var issue struct {
+		Fields map[string]json.RawMessage `json:"fields"`
+	}
+	q := url.Values{"fields": {"*all"}}
+	u := apiURL(cfg, q, "rest/api/3/issue", url.PathEscape(expandKey(cfg, pos[0])))
+	if err := doJSON(cfg, http.MethodGet, u, nil, &issue); err != nil {
+		return err
+	}
+	names, err := getFieldNames(cfg)
+	if err != nil {
+		return err
+	}
+	return writeFields(os.Stdout, issue.Fields, names, *all)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFieldsCmd(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("GET /rest/api/3/issue/IS-1", 200, map[string]any{"fields": map[string]any{
+		"summary":           "Fix the login page",
+		"customfield_10016": 5,
+		"customfield_10020": []map[string]any{{"id": 7, "name": "Sprint 7"}},
+		"description": map[string]any{"type": "doc", "content": []any{
+			map[string]any{"type": "paragraph", "content": []any{map[string]any{"type": "text", "text": "Users see a blank page."}}},
+		}},
+		"labels":      []string{},
+		"environment": nil,
+	}})
+	f.reply("GET /rest/api/3/field", 200, []fieldInfo{
+		{ID: "summary", Name: "Summary"},
+		{ID: "customfield_10016", Name: "Story Points"},
+		{ID: "description", Name: "Description"},
+	})
+
+	var err error
+	out := captureStdout(t, func() { err = fieldsCmd(f.config(), []string{"IS-1"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	var ids []string
+	for _, line := range lines[1:] {
+		ids = append(ids, strings.Fields(line)[0])
+	}
+	if got, want := strings.Join(ids, " "), "customfield_10016 customfield_10020 description summary"; got != want {
+		t.Errorf("field IDs = %s, want %s (sorted, empty ones left out)", got, want)
+	}
+	for _, want := range []string{
+		"customfield_10016  Story Points  5",
+		"customfield_10020  -",
+		"description        Description   Users see a blank page.",
+		"summary            Summary       Fix the login page",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+	if q := f.requests("GET", "/rest/api/3/issue/IS-1")[0].Query.Get("fields"); q != "*all" {
+		t.Errorf("fields = %q, want *all", q)
+	}
+}
+
+func TestPreviewValueTruncates(t *testing.T) {
+	long, _ := json.Marshal(strings.Repeat("word ", 30))
+	got := previewValue(long)
+	if n := len([]rune(got)); n != previewWidth || !strings.HasSuffix(got, "…") {
+		t.Errorf("preview is %d runes, want %d ending in …: %q", n, previewWidth, got)
+	}
+	if got := previewValue(json.RawMessage(`{"a": [1, 2]}`)); got != `{"a":[1,2]}` {
+		t.Errorf("preview of an object = %q, want compact JSON", got)
+	}
+}
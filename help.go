@@ -0,0 +1,206 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// commandHelp is the synopsis and examples each command's -h prints
+// alongside its flags. The synopsis leaves off the leading "jira-cli".
+var commandHelp = map[string]struct {
+	Usage    string
+	Examples []string
+}{
+	"aliases": {"aliases", []string{
+		"jira-cli aliases",
+	}},
+	"api": {"api <METHOD> <path> [body-json|-]", []string{
+		"jira-cli api GET /rest/api/3/myself",
+		`jira-cli api -force PUT /rest/api/3/issue/PROJ-1 '{"fields":{"summary":"New"}}'`,
+	}},
+	"attach": {"attach <KEY> <file>...", []string{
+		"jira-cli attach PROJ-1 build.log",
+		"jira-cli attach PROJ-1 *.png",
+	}},
+	"backlog": {"backlog <KEY>...", []string{
+		"jira-cli backlog PROJ-1 PROJ-2",
+	}},
+	"clone": {"clone <KEY> [-summary TEXT]", []string{
+		"jira-cli clone PROJ-1",
+		`jira-cli clone PROJ-1 -summary "Same again, for iOS"`,
+	}},
+	"component": {"component <KEY> <name>... | component -clear <KEY>", []string{
+		"jira-cli component PROJ-1 Backend API",
+		"jira-cli component -clear PROJ-1",
+	}},
+	"create": {"create [-project KEY] [-type TYPE] <summary>", []string{
+		`jira-cli create "Fix login redirect"`,
+		`jira-cli create -project OPS -type Bug "Disk full on build agent"`,
+	}},
+	"debug-info": {"debug-info", []string{
+		"jira-cli debug-info | pbcopy",
+	}},
+	"detail": {"detail <KEY>", []string{
+		"jira-cli detail PROJ-1",
+	}},
+	"edit": {"edit <KEY> [-description]", []string{
+		"jira-cli edit PROJ-1",
+		"EDITOR=nano jira-cli edit PROJ-1 -description",
+	}},
+	"fields": {"fields <KEY> [-all]", []string{
+		"jira-cli fields PROJ-1",
+		"jira-cli fields PROJ-1 -all | grep -i points",
+	}},
+	"history": {"history <KEY> [-all]", []string{
+		"jira-cli history PROJ-1",
+		"jira-cli history PROJ-1 -all",
+	}},
+	"metrics": {"metrics [-out FILE]", []string{
+		"jira-cli metrics",
+		"jira-cli metrics -out /var/lib/node_exporter/textfile/jira.prom",
+	}},
+	"move": {"move [KEY]", []string{
+		"jira-cli move",
+		"jira-cli move PROJ-1",
+		"jira-cli move -all -yes",
+		"jira-cli move -branch",
+	}},
+	"open": {"open <KEY|-branch>", []string{
+		"jira-cli open PROJ-1",
+		"jira-cli open -branch",
+	}},
+	"ping": {"ping", []string{
+		"jira-cli ping",
+	}},
+	"sprint": {"sprint start <id> -start DATE -end DATE | sprint close <id>", []string{
+		"jira-cli sprint start 42 -start 2024-06-03 -end 2024-06-17",
+		"jira-cli sprint close 42",
+	}},
+	"subtask": {"subtask <PARENT-KEY> <summary>", []string{
+		`jira-cli subtask PROJ-1 "Write the migration"`,
+	}},
+	"summary": {"summary [-json]", []string{
+		"jira-cli summary",
+		"jira-cli summary -json | jq .points",
+	}},
+	"transition": {"transition <KEY|-|-branch> <status>", []string{
+		`jira-cli transition PROJ-1 "In Review"`,
+		"jira-cli transition -branch Done",
+		`printf 'PROJ-1\nPROJ-2\n' | jira-cli transition - Done -from "In Review"`,
+	}},
+}
+
+// helpOut is where -h help goes: stderr, as the flag package does, or
+// stdout when the help was asked for with "jira-cli help".
+var helpOut io.Writer = os.Stderr
+
+// newFlagSet returns the flag set for a command, whose -h prints the
+// command's help.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() { writeCommandHelp(helpOut, name, fs) }
+	return fs
+}
+
+func writeCommandHelp(w io.Writer, name string, fs *flag.FlagSet) {
+	h := commandHelp[name]
+	fmt.Fprintf(w, "usage: jira-cli %s\n", h.Usage)
+	if cmd := lookupCommand(name); cmd != nil {
+		fmt.Fprintf(w, "\n%s.\n", capitalize(cmd.Summary))
+	}
+	hasFlags := false
+	fs.VisitAll(func(*flag.Flag) { hasFlags = true })
+	if hasFlags {
+		fmt.Fprintf(w, "\nFlags:\n")
+		fs.SetOutput(w)
+		fs.PrintDefaults()
+	}
+	if len(h.Examples) > 0 {
+		fmt.Fprintf(w, "\nExamples:\n")
+		for _, e := range h.Examples {
+			fmt.Fprintf(w, "  %s\n", e)
+		}
+	}
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// writeUsage is the top-level help: the command-less forms, every
+// command with its summary, and the global flags.
+func writeUsage(w io.Writer, fs *flag.FlagSet) {
+	fmt.Fprint(w, `usage: jira-cli [flags]                    list your open issues
+       jira-cli [flags] <KEY> <status>     move an issue to a status
+       jira-cli -i                         pick an issue and a status interactively
+       jira-cli -m [KEY]                   move an issue into the active sprint
+       jira-cli <command> [flags] [args]
+
+Commands:
+`)
+	width := 0
+	for _, c := range commands {
+		width = max(width, len(c.Name))
+	}
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-*s  %s\n", width, c.Name, c.Summary)
+	}
+	fmt.Fprintf(w, "\nFlags:\n")
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+	fmt.Fprint(w, `
+Examples:
+  jira-cli -current-sprint -stale 2w
+  jira-cli PROJ-1 "In Progress"
+  jira-cli -m PROJ-1
+
+Run "jira-cli help <command>" or "jira-cli <command> -h" for a command's flags and examples.
+`)
+}
+
+// wantsHelp reports whether args ask for help before any "--".
+func wantsHelp(args []string) bool {
+	for _, a := range args {
+		switch a {
+		case "--":
+			return false
+		case "-h", "-help", "--h", "--help":
+			return true
+		}
+	}
+	return false
+}
+
+// commandUsage prints a command's help and exits. Every command builds
+// its flag set before touching cfg or the network, so running it with
+// -h alone prints the help without needing a configured Jira.
+func commandUsage(cmd *command) {
+	cmd.Run(JiraConfig{}, []string{"-h"})
+	os.Exit(0)
+}
+
+// helpCmd is "jira-cli help [command]". It runs before the Jira
+// configuration is loaded, so it is dispatched from main rather than
+// listed in commands.
+func helpCmd(fs *flag.FlagSet, args []string) error {
+	switch len(args) {
+	case 0:
+		writeUsage(os.Stdout, fs)
+		return nil
+	case 1:
+		cmd := lookupCommand(args[0])
+		if cmd == nil {
+			return usageError("unknown command %q; run \"jira-cli help\" for the list", args[0])
+		}
+		helpOut = os.Stdout
+		commandUsage(cmd)
+		return nil
+	}
+	return usageError("usage: jira-cli help [command]")
+}
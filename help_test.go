@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestUsageListsEveryCommand(t *testing.T) {
+	var b strings.Builder
+	writeUsage(&b, flag.NewFlagSet("jira-cli", flag.ContinueOnError))
+	out := b.String()
+	for _, c := range commands {
+		if !strings.Contains(out, "  "+c.Name+" ") || !strings.Contains(out, c.Summary) {
+			t.Errorf("usage lacks %q (%s)", c.Name, c.Summary)
+		}
+	}
+}
+
+func TestEveryCommandHasHelp(t *testing.T) {
+	for _, c := range commands {
+		h, ok := commandHelp[c.Name]
+		if !ok {
+			t.Errorf("%s has no commandHelp entry", c.Name)
+			continue
+		}
+		if h.Usage != c.Name && !strings.HasPrefix(h.Usage, c.Name+" ") {
+			t.Errorf("%s: usage %q does not start with the command name", c.Name, h.Usage)
+		}
+		if len(h.Examples) == 0 {
+			t.Errorf("%s has no examples", c.Name)
+		}
+
+		var b strings.Builder
+		writeCommandHelp(&b, c.Name, flag.NewFlagSet(c.Name, flag.ContinueOnError))
+		if want := "usage: jira-cli " + h.Usage + "\n\n" + capitalize(c.Summary) + ".\n"; !strings.HasPrefix(b.String(), want) {
+			t.Errorf("%s help:\n%s\nwant it to start with:\n%s", c.Name, b.String(), want)
+		}
+	}
+	for name := range commandHelp {
+		if lookupCommand(name) == nil {
+			t.Errorf("commandHelp has %q, which is not a command", name)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ChangeItem struct {
+	Field      string `json:"field"`
+	FromString string `json:"fromString"`
+	ToString   string `json:"toString"`
+}
+
+type History struct {
+	Author struct {
+		DisplayName string `json:"displayName"`
+	} `json:"author"`
+	Created string       `json:"created"`
+	Items   []ChangeItem `json:"items"`
+}
+
+func getChangelog(cfg JiraConfig, issueKey string) ([]History, error) {
+	var issue struct {
+		Changelog struct {
+			Total     int       `json:"total"`
+			Histories []History `json:"histories"`
+		} `json:"changelog"`
+	}
+
+	u := apiURL(cfg, url.Values{"expand": {"changelog"}}, "rest/api/3/issue", url.PathEscape(issueKey))
+	if err := doJSON(cfg, http.MethodGet, u, nil, &issue); err != nil {
+		return nil, err
+	}
+	if len(issue.Changelog.Histories) >= issue.Changelog.Total {
+		return issue.Changelog.Histories, nil
+	}
+
+	// The embedded changelog is truncated; page through the full one.
+	var all []History
+	for {
+		var page struct {
+			Values []History `json:"values"`
+			IsLast bool      `json:"isLast"`
+		}
+		q := url.Values{"startAt": {strconv.Itoa(len(all))}, "maxResults": {"100"}}
+		u := apiURL(cfg, q, "rest/api/3/issue", url.PathEscape(issueKey), "changelog")
+		if err := doJSON(cfg, http.MethodGet, u, nil, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			return all, nil
+		}
+	}
+}
+
+// formatHistory renders one line per changed field. Unless all is set,
+// only status changes are included.
+func formatHistory(histories []History, all bool, loc *time.Location) string {
+	var lines []string
+	for _, h := range histories {
+		when := h.Created
+		if t, err := parseJiraTime(h.Created); err == nil {
+			when = formatTime(t, loc)
+		}
+		for _, it := range h.Items {
+			if !all && it.Field != "status" {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf(
+				"%s\t%s\t%s: %s → %s",
+				when, h.Author.DisplayName, it.Field, orDash(it.FromString), orDash(it.ToString),
+			))
+		}
+	}
+	if len(lines) == 0 {
+		return "No changes found"
+	}
+	return strings.Join(lines, "\n")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func historyCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("history")
+	all := fs.Bool("all", false, "show every field change, not just status")
+	pos := parseArgs(fs, args)
+	if len(pos) != 1 {
+		return usageError("usage: jira-cli history <KEY> [-all]")
+	}
+
+	histories, err := getChangelog(cfg, expandKey(cfg, pos[0]))
+	if err != nil {
+		return err
+	}
+	loc, err := displayLocation(cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Println(formatHistory(histories, *all, loc))
+	return nil
+}
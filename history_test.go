@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatHistory(t *testing.T) {
+	histories := []History{
+		{Created: "2024-01-05T10:00:00.000+0000", Items: []ChangeItem{
+			{Field: "status", FromString: "Open", ToString: "In Progress"},
+			{Field: "assignee", ToString: "Ann"},
+		}},
+		{Created: "2024-01-06T11:30:00.000+0000", Items: []ChangeItem{
+			{Field: "status", FromString: "In Progress", ToString: "In Review"},
+		}},
+	}
+	histories[0].Author.DisplayName = "Ann"
+	histories[1].Author.DisplayName = "Bob"
+
+	got := formatHistory(histories, false, time.UTC)
+	want := "2024-01-05 10:00\tAnn\tstatus: Open → In Progress\n" +
+		"2024-01-06 11:30\tBob\tstatus: In Progress → In Review"
+	if got != want {
+		t.Errorf("status history:\n%s\nwant:\n%s", got, want)
+	}
+
+	got = formatHistory(histories, true, time.UTC)
+	want = "2024-01-05 10:00\tAnn\tstatus: Open → In Progress\n" +
+		"2024-01-05 10:00\tAnn\tassignee: - → Ann\n" +
+		"2024-01-06 11:30\tBob\tstatus: In Progress → In Review"
+	if got != want {
+		t.Errorf("full history:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := formatHistory(nil, false, time.UTC); got != "No changes found" {
+		t.Errorf("empty history = %q", got)
+	}
+}
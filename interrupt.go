@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+)
+
+// rootCtx is the context every request runs under. Ctrl-C cancels it.
+var rootCtx = context.Background()
+
+var (
+	cleanupMu sync.Mutex
+	cleanups  = map[int]func(){}
+	cleanupID int
+)
+
+// atInterrupt registers f to run if the process is interrupted, since
+// deferred calls are skipped by the exit. Call the returned function once
+// the cleanup is no longer needed, typically with defer.
+func atInterrupt(f func()) (remove func()) {
+	cleanupMu.Lock()
+	defer cleanupMu.Unlock()
+	cleanupID++
+	id := cleanupID
+	cleanups[id] = f
+	return func() {
+		cleanupMu.Lock()
+		defer cleanupMu.Unlock()
+		delete(cleanups, id)
+	}
+}
+
+// runCleanups runs the registered hooks, newest first, and clears them.
+func runCleanups() {
+	cleanupMu.Lock()
+	fs, last := cleanups, cleanupID
+	cleanups = map[int]func(){}
+	cleanupMu.Unlock()
+	for id := last; id > 0; id-- {
+		if f, ok := fs[id]; ok {
+			f()
+		}
+	}
+}
+
+var (
+	cancelRoot    context.CancelFunc = func() {}
+	interruptOnce sync.Once
+
+	// childOwnsTerminal is set while a child such as $EDITOR runs in the
+	// foreground. It gets the same Ctrl-C and handles it itself.
+	childOwnsTerminal atomic.Bool
+)
+
+// exitInterrupted cancels outstanding requests, runs the cleanup hooks
+// and exits with the conventional 128+SIGINT status. Later callers block
+// until the first one exits.
+func exitInterrupted() {
+	interruptOnce.Do(func() {
+		cancelRoot()
+		runCleanups()
+		fmt.Fprintln(os.Stderr, "aborted")
+		os.Exit(130)
+	})
+}
+
+// handleInterrupts makes Ctrl-C cancel rootCtx and exit via
+// exitInterrupted, including while blocked on a prompt.
+func handleInterrupts() {
+	rootCtx, cancelRoot = context.WithCancel(context.Background())
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt)
+	go func() {
+		for range sigs {
+			if !childOwnsTerminal.Load() {
+				exitInterrupted()
+			}
+		}
+	}()
+}
+
+// withTerminalChild runs f, which hands the terminal to a child process,
+// without exiting on the Ctrl-Cs meant for that child.
+func withTerminalChild(f func() error) error {
+	childOwnsTerminal.Store(true)
+	defer childOwnsTerminal.Store(false)
+	return f()
+}
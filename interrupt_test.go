@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestInterruptCancelsRequests(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	setGlobal(t, &rootCtx, ctx)
+	f := newFakeJira(t)
+	arrived := make(chan struct{})
+	f.mux.HandleFunc("GET /rest/api/3/myself", func(w http.ResponseWriter, r *http.Request) {
+		close(arrived)
+		<-r.Context().Done()
+	})
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := getMyself(f.config())
+		errc <- err
+	}()
+	<-arrived
+	cancel()
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not cancelled")
+	}
+}
+
+func TestRunCleanups(t *testing.T) {
+	var ran []string
+	atInterrupt(func() { ran = append(ran, "first") })
+	remove := atInterrupt(func() { ran = append(ran, "removed") })
+	atInterrupt(func() { ran = append(ran, "last") })
+	remove()
+
+	runCleanups()
+	if want := []string{"last", "first"}; !slices.Equal(ran, want) {
+		t.Errorf("ran %q, want %q", ran, want)
+	}
+	runCleanups()
+	if len(ran) != 2 {
+		t.Errorf("hooks ran twice: %q", ran)
+	}
+}
+
+// TestInterruptExit sends the test binary itself a SIGINT from a child
+// process, since the handler exits.
+func TestInterruptExit(t *testing.T) {
+	if marker := os.Getenv("JIRA_CLI_TEST_INTERRUPT"); marker != "" {
+		handleInterrupts()
+		atInterrupt(func() { os.WriteFile(marker, nil, 0o644) })
+		p, _ := os.FindProcess(os.Getpid())
+		p.Signal(os.Interrupt)
+		<-rootCtx.Done()
+		time.Sleep(5 * time.Second)
+		os.Exit(0)
+	}
+	if runtime.GOOS == "windows" {
+		t.Skip("no SIGINT on Windows")
+	}
+
+	marker := filepath.Join(t.TempDir(), "cleaned")
+	cmd := exec.Command(os.Args[0], "-test.run=^TestInterruptExit$")
+	cmd.Env = append(os.Environ(), "JIRA_CLI_TEST_INTERRUPT="+marker)
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 130 {
+		t.Errorf("child exited with %v, want status 130", err)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Errorf("cleanup hook did not run: %v", err)
+	}
+}
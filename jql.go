@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// issueQuery describes which issues a listing covers. The zero value is
+// the default "my open issues" query.
+type issueQuery struct {
+	// Assignee replaces the "assignee = currentUser()" clause, for
+	// instances that restrict currentUser() or use another people field.
+	Assignee string
+
+	// Team lists issues assigned to members of this group instead of
+	// the current user.
+	Team string
+
+	// IncludeEpics drops the default epic exclusion.
+	IncludeEpics bool
+
+	// NoSubtasks hides subtasks.
+	NoSubtasks bool
+
+	// Category is a status category key (new, indeterminate or done)
+	// that replaces the default "not done" clause.
+	Category string
+
+	// Raw, when set, replaces the composed query entirely.
+	Raw string
+}
+
+func quoteJQL(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// clauseOperator matches the comparison every JQL clause needs.
+var clauseOperator = regexp.MustCompile(`(?i)(!?=|!?~|[<>]=?|\s(not\s+)?in[\s(]|\sis\s|\swas\s|\schanged\b)`)
+
+// checkClause does a light sanity check on a user-supplied JQL clause:
+// it must have an operator and cannot carry its own ORDER BY, which would
+// break the clauses joined after it.
+func checkClause(c string) error {
+	c = strings.TrimSpace(c)
+	switch {
+	case c == "":
+		return errors.New("clause is empty")
+	case strings.Contains(strings.ToLower(c), "order by"):
+		return errors.New("clause cannot contain ORDER BY")
+	case !clauseOperator.MatchString(c):
+		return errors.New("clause has no JQL operator, e.g. \"assignee = currentUser()\"")
+	}
+	return nil
+}
+
+func (q issueQuery) JQL() string {
+	if q.Raw != "" {
+		return q.Raw
+	}
+
+	assignee := "assignee = currentUser()"
+	if q.Assignee != "" {
+		assignee = "(" + q.Assignee + ")"
+	}
+	if q.Team != "" {
+		assignee = "assignee in membersOf(" + quoteJQL(q.Team) + ")"
+	}
+	category := "statusCategory != Done"
+	if q.Category != "" {
+		category = "statusCategory = " + quoteJQL(q.Category)
+	}
+	clauses := []string{assignee, category}
+	if !q.IncludeEpics {
+		clauses = append(clauses, "issuetype != Epic")
+	}
+	if q.NoSubtasks {
+		clauses = append(clauses, "issuetype not in subtaskIssueTypes()")
+	}
+	return strings.Join(clauses, " AND ")
+}
+
+// BoardJQL is what narrows a board's own filter: the clauses for
+// -team, -category and -no-subtasks, and -jql, ANDed together. The
+// default assignee, not-done and epic clauses are left to the board.
+func (q issueQuery) BoardJQL() string {
+	var clauses []string
+	if q.Team != "" {
+		clauses = append(clauses, "assignee in membersOf("+quoteJQL(q.Team)+")")
+	}
+	if q.Category != "" {
+		clauses = append(clauses, "statusCategory = "+quoteJQL(q.Category))
+	}
+	if q.NoSubtasks {
+		clauses = append(clauses, "issuetype not in subtaskIssueTypes()")
+	}
+	if q.Raw != "" {
+		if len(clauses) == 0 {
+			return q.Raw
+		}
+		clauses = append(clauses, "("+q.Raw+")")
+	}
+	return strings.Join(clauses, " AND ")
+}
+
+// statusCategories maps -category names to Jira's status category keys.
+var statusCategories = map[string]string{
+	"todo":       "new",
+	"inprogress": "indeterminate",
+	"done":       "done",
+}
+
+// parseCategory maps a -category value to its key, ignoring case and
+// the spaces, hyphens and underscores in "in-progress" or "To Do".
+func parseCategory(s string) (string, error) {
+	norm := strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(s))
+	if key, ok := statusCategories[norm]; ok {
+		return key, nil
+	}
+	return "", usageError("invalid -category %q (want todo, inprogress or done)", s)
+}
+
+// optionalString is a string flag that records whether it was given,
+// so an explicitly empty value can be rejected.
+type optionalString struct {
+	Given bool
+	Value string
+}
+
+func (o *optionalString) String() string { return o.Value }
+
+func (o *optionalString) Set(v string) error {
+	o.Given, o.Value = true, v
+	return nil
+}
+
+// stringList is a repeatable flag that also splits on commas.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(v string) error {
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			*l = append(*l, s)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTeamQueryUsesMembersOf(t *testing.T) {
+	lo := listOptions{}
+	if err := lo.Team.Set(` platform "core" `); err != nil {
+		t.Fatal(err)
+	}
+	q, err := lo.query(JiraConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `assignee in membersOf("platform \"core\"") AND statusCategory != Done AND issuetype != Epic`
+	if got := q.JQL(); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	if got := (issueQuery{}).JQL(); got != "assignee = currentUser() AND statusCategory != Done AND issuetype != Epic" {
+		t.Errorf("default query = %s", got)
+	}
+
+	empty := listOptions{}
+	empty.Team.Set("  ")
+	if _, err := empty.query(JiraConfig{}); !errors.Is(err, ErrUsage) {
+		t.Errorf("empty -team: err = %v, want a usage error", err)
+	}
+}
+
+func TestIncludeEpicsDropsEpicExclusion(t *testing.T) {
+	q, err := listOptions{Epics: true}.query(JiraConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := q.JQL(), "assignee = currentUser() AND statusCategory != Done"; got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+	// A full -jql override is left alone.
+	q, _ = listOptions{Epics: true, JQL: "project = X AND issuetype != Epic"}.query(JiraConfig{})
+	if got := q.JQL(); got != "project = X AND issuetype != Epic" {
+		t.Errorf("-jql override changed to %s", got)
+	}
+}
+
+func TestNoSubtasksQuery(t *testing.T) {
+	q, _ := listOptions{NoSubtasks: true}.query(JiraConfig{})
+	want := "assignee = currentUser() AND statusCategory != Done AND issuetype != Epic AND issuetype not in subtaskIssueTypes()"
+	if got := q.JQL(); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+	q, _ = listOptions{NoSubtasks: true, JQL: "project = X"}.query(JiraConfig{})
+	if got := q.JQL(); got != "project = X" {
+		t.Errorf("-jql override changed to %s", got)
+	}
+}
+
+func TestAssigneeClause(t *testing.T) {
+	cfg := JiraConfig{AssigneeClause: "cf[10100] = currentUser()"}
+	q, _ := listOptions{}.query(cfg)
+	want := "(cf[10100] = currentUser()) AND statusCategory != Done AND issuetype != Epic"
+	if got := q.JQL(); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	f := newFakeJira(t)
+	f.reply("POST /rest/api/3/search/jql", 200, searchResult())
+	cfg = f.config()
+	cfg.AssigneeClause = "reporter = currentUser()"
+	if _, err := getIssues(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if reqs := f.requests("POST", searchJQLPath); len(reqs) != 1 || !strings.Contains(reqs[0].Body, `"jql":"(reporter = currentUser()) AND `) {
+		t.Errorf("search requests = %+v", reqs)
+	}
+}
+
+func TestCheckClause(t *testing.T) {
+	for _, c := range []string{"assignee = currentUser()", "assignee in membersOf(\"x\")", "assignee was currentUser()", "cf[1] ~ \"me\""} {
+		if err := checkClause(c); err != nil {
+			t.Errorf("checkClause(%q) = %v", c, err)
+		}
+	}
+	for _, c := range []string{"", "  ", "currentUser()", "assignee = x ORDER BY key"} {
+		if err := checkClause(c); err == nil {
+			t.Errorf("checkClause(%q) accepted", c)
+		}
+	}
+}
+
+func TestJQLFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "q.jql")
+	os.WriteFile(path, []byte("\n  project = X\n  AND labels = urgent\n\n"), 0o644)
+
+	q, err := listOptions{JQLFile: path}.query(JiraConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := q.JQL(), "project = X\n  AND labels = urgent"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	empty := filepath.Join(dir, "empty.jql")
+	os.WriteFile(empty, []byte(" \n\t\n"), 0o644)
+	for name, lo := range map[string]listOptions{
+		"empty file":   {JQLFile: empty},
+		"missing file": {JQLFile: filepath.Join(dir, "nope.jql")},
+		"with -jql":    {JQLFile: path, JQL: "project = Y"},
+	} {
+		if _, err := lo.query(JiraConfig{}); !errors.Is(err, ErrUsage) {
+			t.Errorf("%s: err = %v, want a usage error", name, err)
+		}
+	}
+}
+
+func TestCategoryQuery(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"todo", "new"},
+		{"To Do", "new"},
+		{"in-progress", "indeterminate"},
+		{"IN_PROGRESS", "indeterminate"},
+		{"inprogress", "indeterminate"},
+		{"Done", "done"},
+	}
+	for _, tt := range tests {
+		if got, err := parseCategory(tt.in); err != nil || got != tt.want {
+			t.Errorf("parseCategory(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := parseCategory("blocked"); !errors.Is(err, ErrUsage) {
+		t.Errorf("parseCategory(blocked): err = %v, want a usage error", err)
+	}
+
+	// The category replaces the default not-done clause.
+	q, err := listOptions{Category: "done"}.query(JiraConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := q.JQL(), `assignee = currentUser() AND statusCategory = "done" AND issuetype != Epic`; got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestBoardJQL(t *testing.T) {
+	tests := []struct {
+		q    issueQuery
+		want string
+	}{
+		{issueQuery{Assignee: "reporter = currentUser()"}, ""},
+		{issueQuery{Raw: "labels = api"}, "labels = api"},
+		{issueQuery{Category: "done", Raw: "labels = api"}, `statusCategory = "done" AND (labels = api)`},
+	}
+	for _, tt := range tests {
+		if got := tt.q.BoardJQL(); got != tt.want {
+			t.Errorf("%+v: BoardJQL() = %q, want %q", tt.q, got, tt.want)
+		}
+	}
+}
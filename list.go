@@ -0,0 +1,486 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// listOptions holds the flags that shape the default issue listing.
+type listOptions struct {
+	Links          bool
+	Columns        string
+	Template       string
+	CountBy        string
+	Team           optionalString
+	Format         string
+	Epics          bool
+	JQL            string
+	Delta          bool
+	ResetDelta     bool
+	Align          bool
+	TSV            bool
+	Flat           bool
+	NoSubtasks     bool
+	Explain        bool
+	Compact        bool
+	Sort           string
+	GroupClosedAs  string
+	Output         string
+	TemplateFile   string
+	Wrap           bool
+	Width          int
+	CurrentSprint  bool
+	Time           bool
+	JQLFile        string
+	Stale          string
+	FieldMap       string
+	Category       string
+	Epic           bool
+	Board          int
+	Sprint         string
+	PointsProgress bool
+}
+
+func (lo *listOptions) register(fs *flag.FlagSet) {
+	fs.BoolVar(&lo.Links, "links", false, "render issue keys as clickable terminal hyperlinks")
+	fs.StringVar(&lo.Columns, "columns", "", "comma-separated columns to show, in order (key,points,estimate,remaining,spent,status,type,summary,sprint,components,epic,assignee)")
+	fs.StringVar(&lo.FieldMap, "field-map", "", "show custom fields as columns, e.g. customfield_10050=Team,customfield_10060=QA")
+	fs.StringVar(&lo.Template, "template", "", "render each issue with a Go text/template")
+	fs.StringVar(&lo.TemplateFile, "template-file", "", "like -template, but read the template from this file")
+	fs.StringVar(&lo.CountBy, "count-by", "", "print issue counts grouped by status, type, sprint or assignee")
+	fs.StringVar(&lo.Sort, "sort", "", "sort issues, e.g. points:desc,key:asc (fields: key, points, status, type, updated)")
+	fs.StringVar(&lo.GroupClosedAs, "group-closed-as", "", "list issues whose sprint is closed under one group with this name, e.g. Closed")
+	fs.BoolVar(&lo.Time, "time", false, "show time estimates instead of points, with remaining time per sprint")
+	fs.BoolVar(&lo.PointsProgress, "points-progress", false, "also show the points of done issues in sprint headers, e.g. (8 pts, 3 done)")
+	fs.BoolVar(&lo.Compact, "compact", false, "print only one summary line per sprint")
+	fs.BoolVar(&lo.Flat, "flat", false, "one table of all issues, without sprint grouping")
+	fs.BoolVar(&lo.Wrap, "wrap", false, "wrap long summaries onto indented lines instead of running past the terminal edge")
+	fs.IntVar(&lo.Width, "width", 0, "line width for -wrap (implies -wrap; default: terminal width, or 100)")
+	fs.BoolVar(&lo.Align, "align", false, "align columns with spaces instead of tabs")
+	fs.StringVar(&lo.Output, "o", "", "output format: jsonl prints one JSON object per issue, keys one sorted issue key per line")
+	fs.BoolVar(&lo.TSV, "tsv", false, "print plain tab-separated values with a header row")
+	fs.StringVar(&lo.Format, "format", "", "listing format: relative-points draws point bars (terminal only)")
+	fs.Var(&lo.Team, "team", "list issues assigned to members of this group instead of you")
+	fs.BoolVar(&lo.Epic, "epic", false, "show each issue's epic in an extra column")
+	fs.BoolVar(&lo.Epics, "include-epics", false, "include epics in the listing")
+	fs.BoolVar(&lo.Delta, "delta", false, "show only issues that are new, changed or gone since the last -delta run")
+	fs.BoolVar(&lo.ResetDelta, "delta-reset", false, "forget the snapshot used by -delta")
+	fs.StringVar(&lo.Stale, "stale", "", "mark issues not updated for this long, e.g. 14d, 2w or 36h")
+	fs.IntVar(&lo.Board, "board", 0, "list the issues on this board ID, through the board's own filter")
+	fs.StringVar(&lo.Sprint, "sprint", "", "with -board, only the issues in this active or future sprint")
+	fs.BoolVar(&lo.CurrentSprint, "current-sprint", false, "only issues in the active sprint")
+	fs.StringVar(&lo.Category, "category", "", "only issues in this status category: todo, inprogress or done (replaces the default not-done filter)")
+	fs.BoolVar(&lo.NoSubtasks, "no-subtasks", false, "hide subtasks")
+	fs.BoolVar(&lo.Explain, "explain", false, "print the query that would run, without running it")
+	fs.StringVar(&lo.JQL, "jql", "", "run this JQL instead of the default query (other query flags are ignored)")
+	fs.StringVar(&lo.JQLFile, "jql-file", "", "like -jql, but read the query from this file")
+}
+
+func (lo listOptions) query(cfg JiraConfig) (issueQuery, error) {
+	q := issueQuery{
+		Assignee:     cfg.AssigneeClause,
+		IncludeEpics: lo.Epics,
+		NoSubtasks:   lo.NoSubtasks,
+		Raw:          strings.TrimSpace(lo.JQL),
+	}
+	if lo.Team.Given {
+		q.Team = strings.TrimSpace(lo.Team.Value)
+		if q.Team == "" {
+			return q, usageError("-team needs a group name")
+		}
+	}
+	if lo.Category != "" {
+		key, err := parseCategory(lo.Category)
+		if err != nil {
+			return q, err
+		}
+		q.Category = key
+	}
+	if lo.JQLFile != "" {
+		if lo.JQL != "" {
+			return q, usageError("-jql and -jql-file cannot be combined")
+		}
+		b, err := os.ReadFile(lo.JQLFile)
+		if err != nil {
+			return q, usageError("reading -jql-file: %v", err)
+		}
+		if q.Raw = strings.TrimSpace(string(b)); q.Raw == "" {
+			return q, usageError("-jql-file %s is empty", lo.JQLFile)
+		}
+	}
+	return q, nil
+}
+
+func assigneeName(ji JiraIssue) string {
+	if ji.Fields.Assignee == nil || ji.Fields.Assignee.DisplayName == "" {
+		return "Unassigned"
+	}
+	return ji.Fields.Assignee.DisplayName
+}
+
+// groupFields extracts the value an issue is grouped by for -count-by.
+var groupFields = map[string]func(JiraIssue) string{
+	"status":   func(ji JiraIssue) string { return ji.Fields.Status.Name },
+	"type":     func(ji JiraIssue) string { return ji.Fields.IssueType.Name },
+	"sprint":   func(ji JiraIssue) string { return sprintName(ji.Fields.Sprints) },
+	"assignee": assigneeName,
+}
+
+type groupCount struct {
+	Value string
+	Count int
+}
+
+// countBy tallies issues by field, largest group first and ties broken
+// alphabetically.
+func countBy(issues []JiraIssue, field func(JiraIssue) string) []groupCount {
+	counts := map[string]int{}
+	for _, ji := range issues {
+		counts[field(ji)]++
+	}
+	out := make([]groupCount, 0, len(counts))
+	for v, n := range counts {
+		out = append(out, groupCount{v, n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Value < out[j].Value
+	})
+	return out
+}
+
+func formatCounts(counts []groupCount) string {
+	var lines []string
+	total := 0
+	for _, c := range counts {
+		lines = append(lines, fmt.Sprintf("%s: %d", c.Value, c.Count))
+		total += c.Count
+	}
+	lines = append(lines, fmt.Sprintf("Total: %d", total))
+	return strings.Join(lines, "\n")
+}
+
+var errNoActiveSprint = fmt.Errorf("%w: none of the issues are in an active sprint", ErrNotFound)
+
+// inActiveSprint keeps the issues whose current sprint is active, the
+// same one they would be grouped under.
+func inActiveSprint(issues []JiraIssue) []JiraIssue {
+	var out []JiraIssue
+	for _, ji := range issues {
+		if sp := currentSprint(ji.Fields.Sprints); sp != nil && sp.State == "active" {
+			out = append(out, ji)
+		}
+	}
+	return out
+}
+
+// writeJSONL writes each issue as it came from Jira, one compact JSON
+// object per line, so -field-map and other custom fields are kept.
+// Issues that weren't decoded from a response are encoded as they are.
+func writeJSONL(w io.Writer, issues []JiraIssue) error {
+	var b bytes.Buffer
+	for _, ji := range issues {
+		b.Reset()
+		if ji.Raw != nil {
+			if err := json.Compact(&b, ji.Raw); err != nil {
+				return err
+			}
+		} else {
+			buf, err := json.Marshal(ji)
+			if err != nil {
+				return err
+			}
+			b.Write(buf)
+		}
+		b.WriteByte('\n')
+		if _, err := w.Write(b.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func listFlow(cfg JiraConfig, lo listOptions) error {
+	var opts formatOptions
+	var mapped []mappedField
+	if lo.FieldMap != "" {
+		m, err := parseFieldMap(lo.FieldMap)
+		if err != nil {
+			return err
+		}
+		registerFieldMap(m)
+		mapped = m
+	}
+	if lo.Columns != "" {
+		cols, err := parseColumns(lo.Columns)
+		if err != nil {
+			return err
+		}
+		opts.Columns = cols
+	} else {
+		base := defaultColumns
+		if lo.Time {
+			base = timeColumns
+		}
+		if lo.Team.Given {
+			// Everyone's issues look alike without saying whose they are.
+			base = append(base[:len(base):len(base)], "assignee")
+		}
+		if lo.Epic {
+			base = append(base[:len(base):len(base)], "epic")
+		}
+		for _, f := range mapped {
+			base = append(base[:len(base):len(base)], f.Header)
+		}
+		opts.Columns = base
+	}
+	showEpics := slices.Contains(opts.Columns, "epic")
+	if showEpics {
+		wantEpics(cfg)
+	}
+	opts.Time = lo.Time
+	opts.PointsProgress = lo.PointsProgress
+	if lo.Stale != "" {
+		age, err := parseAge(lo.Stale)
+		if err != nil {
+			return err
+		}
+		opts.StaleBefore = time.Now().Add(-age)
+	}
+
+	if lo.Align && lo.TSV {
+		return usageError("-align and -tsv cannot be combined")
+	}
+	if lo.PointsProgress && lo.Time {
+		return usageError("-points-progress and -time cannot be combined")
+	}
+	opts.Padded = lo.Align
+	opts.Compact = lo.Compact
+	switch {
+	case lo.Width < 0:
+		return usageError("-width must be positive")
+	case lo.Width > 0:
+		opts.Wrap = lo.Width
+	case lo.Wrap:
+		opts.Wrap = terminalWidth(os.Stdout)
+	}
+	opts.GroupClosedAs = strings.TrimSpace(lo.GroupClosedAs)
+
+	switch lo.Format {
+	case "":
+	case "relative-points":
+		opts.PointBars = isTerminal(os.Stdout)
+	default:
+		return usageError("invalid -format %q (want relative-points)", lo.Format)
+	}
+
+	if lo.Template != "" && lo.TemplateFile != "" {
+		return usageError("-template and -template-file cannot be combined")
+	}
+	text := lo.Template
+	if lo.TemplateFile != "" {
+		b, err := os.ReadFile(lo.TemplateFile)
+		if err != nil {
+			return usageError("reading -template-file: %v", err)
+		}
+		// Editors end files with a newline; each issue gets one anyway.
+		text = strings.TrimSuffix(string(b), "\n")
+	}
+	var tmpl *template.Template
+	if text != "" {
+		t, err := parseIssueTemplate(text)
+		if err != nil {
+			return err
+		}
+		tmpl = t
+	}
+
+	var sortKeys []sortKey
+	if lo.Sort != "" {
+		keys, err := parseSortSpec(lo.Sort)
+		if err != nil {
+			return err
+		}
+		sortKeys = keys
+	}
+
+	switch lo.Output {
+	case "":
+	case "jsonl", "keys":
+		if tmpl != nil || lo.TSV || lo.CountBy != "" || lo.Delta {
+			return usageError("-o %s cannot be combined with -template(-file), -tsv, -count-by or -delta", lo.Output)
+		}
+	default:
+		return usageError("invalid -o %q (want jsonl or keys)", lo.Output)
+	}
+
+	var groupField func(JiraIssue) string
+	if lo.CountBy != "" {
+		groupField = groupFields[lo.CountBy]
+		if groupField == nil {
+			return usageError("invalid -count-by %q (want status, type, sprint or assignee)", lo.CountBy)
+		}
+	}
+
+	q, err := lo.query(cfg)
+	if err != nil {
+		return err
+	}
+
+	scope := boardScope{BoardID: lo.Board, Sprint: strings.TrimSpace(lo.Sprint)}
+	switch {
+	case lo.Board < 0:
+		return usageError("-board must be a positive board ID")
+	case lo.Sprint != "" && lo.Board == 0:
+		return usageError("-sprint needs -board")
+	case lo.Board != 0 && lo.Epics:
+		return usageError("-include-epics has no effect with -board, which lists the board's epics as it shows them")
+	}
+	jql := q.JQL()
+	if scope.BoardID != 0 {
+		// The board's filter picks the issues; the query flags only
+		// narrow it.
+		jql = q.BoardJQL()
+	}
+	if lo.Explain {
+		if scope.BoardID != 0 {
+			fmt.Printf("Board:  %d", scope.BoardID)
+			if scope.Sprint != "" {
+				fmt.Printf(", sprint %q", scope.Sprint)
+			}
+			fmt.Println()
+		}
+		fmt.Printf("JQL:    %s\nFields: %s\n", orDash(jql), strings.Join(searchFields, ","))
+		return nil
+	}
+	// -current-sprint filters after the search, so its snapshot must not
+	// be mixed up with the unfiltered one.
+	snapKey := jql
+	if scope.BoardID != 0 {
+		snapKey = fmt.Sprintf("board %d sprint %q: %s", scope.BoardID, scope.Sprint, jql)
+	}
+	if lo.CurrentSprint {
+		snapKey += " (current sprint)"
+	}
+	if lo.ResetDelta {
+		return resetDelta(snapKey)
+	}
+
+	// Without -sort there is nothing to wait for, so lines go out page
+	// by page.
+	if lo.Output == "jsonl" && sortKeys == nil {
+		n := 0
+		err := scope.issuesEach(cfg, jql, func(page []JiraIssue) error {
+			if lo.CurrentSprint {
+				page = inActiveSprint(page)
+			}
+			n += len(page)
+			return writeJSONL(os.Stdout, page)
+		})
+		if err == nil && lo.CurrentSprint && n == 0 {
+			err = errNoActiveSprint
+		}
+		return err
+	}
+
+	issues, err := scope.issues(cfg, jql)
+	if err != nil {
+		return err
+	}
+	if lo.CurrentSprint {
+		if issues = inActiveSprint(issues); len(issues) == 0 {
+			return errNoActiveSprint
+		}
+	}
+
+	if lo.Output == "jsonl" {
+		sortIssues(issues, sortKeys)
+		return writeJSONL(os.Stdout, issues)
+	}
+	if lo.Output == "keys" {
+		if sortKeys == nil {
+			sortKeys = []sortKey{{Field: "key"}}
+		}
+		sortIssues(issues, sortKeys)
+		for _, ji := range issues {
+			fmt.Println(ji.Key)
+		}
+		return nil
+	}
+
+	if lo.Delta {
+		return deltaFlow(snapKey, issues)
+	}
+
+	if groupField != nil {
+		fmt.Println(formatCounts(countBy(issues, groupField)))
+		return nil
+	}
+
+	if tmpl != nil {
+		out, err := renderTemplate(tmpl, issues)
+		if err != nil {
+			return err
+		}
+		if out != "" {
+			fmt.Println(out)
+		}
+		return nil
+	}
+
+	if showEpics {
+		opts.EpicNames = epicNames(cfg, issues)
+	}
+	if lo.TSV {
+		fmt.Println(formatTSV(issues, opts))
+		return nil
+	}
+
+	fillSprintDates(cfg, issues)
+
+	if lo.Links && isTerminal(os.Stdout) {
+		opts.LinkBase = cfg.URL
+	}
+	colors, priority, err := labelStyles(cfg.File)
+	if err != nil {
+		return err
+	}
+	opts.LabelPriority = priority
+	if colorEnabled(os.Stdout) {
+		for label, code := range activeTheme.Labels {
+			if colors == nil {
+				colors = map[string]string{}
+			}
+			colors[label] = code
+		}
+		opts.LabelColors = colors
+		opts.Theme = &activeTheme
+	}
+	if lo.Flat {
+		if sortKeys == nil {
+			sortKeys = []sortKey{{Field: "key"}}
+		}
+		sortIssues(issues, sortKeys)
+		fmt.Println(formatFlat(issues, opts))
+	} else {
+		if sortKeys != nil {
+			sortIssues(issues, sortKeys)
+		}
+		fmt.Println(formatIssuesBySprint(issues, opts))
+	}
+	if s := formatStale(issues, opts.StaleBefore, lo.Stale); s != "" {
+		fmt.Println(s)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCountByStatus(t *testing.T) {
+	issues := []JiraIssue{
+		sampleIssue("IS-1", "Open", 1),
+		sampleIssue("IS-2", "In Progress", 1),
+		sampleIssue("IS-3", "Open", 1),
+		sampleIssue("IS-4", "Done", 1),
+		sampleIssue("IS-5", "In Progress", 1),
+		sampleIssue("IS-6", "Open", 1),
+	}
+	got := formatCounts(countBy(issues, groupFields["status"]))
+	// Largest group first, ties alphabetically.
+	want := "Open: 3\nIn Progress: 2\nDone: 1\nTotal: 6"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestOutputJSONL(t *testing.T) {
+	f := newFakeJira(t)
+	f.mux.HandleFunc("POST /rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"isLast": true, "issues": [
+			{"key": "IS-2", "fields": {"summary": "Second", "customfield_10050": {"value": "Payments"}}},
+			{"key": "IS-1", "fields": {"summary": "First\nline two"}}
+		]}`))
+	})
+
+	var err error
+	out := captureStdout(t, func() { err = listFlow(f.config(), listOptions{Output: "jsonl", Sort: "key"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("%d lines, want one per issue:\n%s", len(lines), out)
+	}
+	var keys []string
+	for _, l := range lines {
+		var v struct {
+			Key    string
+			Fields map[string]json.RawMessage
+		}
+		if err := json.Unmarshal([]byte(l), &v); err != nil {
+			t.Fatalf("line %q is not JSON: %v", l, err)
+		}
+		keys = append(keys, v.Key)
+	}
+	if !slices.Equal(keys, []string{"IS-1", "IS-2"}) {
+		t.Errorf("keys = %v, want sorted IS-1, IS-2", keys)
+	}
+	// Fields jira-cli doesn't decode are passed through.
+	if !strings.Contains(lines[1], `"customfield_10050":{"value":"Payments"}`) {
+		t.Errorf("custom field dropped: %s", lines[1])
+	}
+
+	// Without -sort, lines stream out in the order Jira returned them.
+	out = captureStdout(t, func() { err = listFlow(f.config(), listOptions{Output: "jsonl"}) })
+	if err != nil || !strings.HasPrefix(out, `{"key":"IS-2"`) || strings.Count(out, "\n") != 2 {
+		t.Errorf("streamed output = %q, %v", out, err)
+	}
+}
+
+func TestCurrentSprintFilter(t *testing.T) {
+	active, closed, backlog := sampleIssue("IS-1", "Open", 1), sampleIssue("IS-2", "Open", 1), sampleIssue("IS-3", "Open", 1)
+	active.Fields.Sprints = []Sprint{{ID: 1, Name: "Old", State: "closed", EndDate: "2024-01-01T00:00:00.000+0000"}, {ID: 2, Name: "Now", State: "active"}}
+	closed.Fields.Sprints = []Sprint{{ID: 1, Name: "Old", State: "closed"}}
+	f := newFakeJira(t)
+	f.reply("POST /rest/api/3/search/jql", 200, searchResult(active, closed, backlog))
+
+	var err error
+	out := captureStdout(t, func() { err = listFlow(f.config(), listOptions{CurrentSprint: true, Output: "keys"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "IS-1\n" {
+		t.Errorf("output = %q, want only IS-1", out)
+	}
+
+	g := newFakeJira(t)
+	g.reply("POST /rest/api/3/search/jql", 200, searchResult(closed, backlog))
+	if err := listFlow(g.config(), listOptions{CurrentSprint: true}); !errors.Is(err, errNoActiveSprint) {
+		t.Errorf("no active sprint: err = %v, want errNoActiveSprint", err)
+	}
+}
+
+func TestPointsProgress(t *testing.T) {
+	sprint := []Sprint{{ID: 7, Name: "Sprint 7", State: "active"}}
+	var issues []JiraIssue
+	for _, ji := range []JiraIssue{
+		categoryIssue("IS-1", "done"),
+		categoryIssue("IS-2", "done"),
+		categoryIssue("IS-3", "indeterminate"),
+	} {
+		ji.Fields.Sprints = sprint
+		issues = append(issues, ji)
+	}
+	issues[0].Fields.Points = 3
+	issues[1].Fields.Points = 2.5
+	issues[2].Fields.Points = 5
+
+	out := formatIssuesBySprint(issues, formatOptions{PointsProgress: true})
+	if !strings.Contains(out, "Sprint: Sprint 7 (3 issues, 10.5 pts, 5.5 done)") {
+		t.Errorf("header lacks the done subtotal:\n%s", out)
+	}
+	if out := formatIssuesBySprint(issues, formatOptions{}); strings.Contains(out, "done)") {
+		t.Errorf("done subtotal without -points-progress:\n%s", out)
+	}
+
+	err := listFlow(JiraConfig{}, listOptions{PointsProgress: true, Time: true})
+	if !errors.Is(err, ErrUsage) || !strings.Contains(err.Error(), "-points-progress and -time cannot be combined") {
+		t.Errorf("-points-progress with -time: err = %v", err)
+	}
+}
+
+func TestOutputKeys(t *testing.T) {
+	sprinted := sampleIssue("IS-10", "Open", 3)
+	sprinted.Fields.Sprints = []Sprint{{ID: 2, Name: "Now", State: "active"}}
+	f := newFakeJira(t)
+	f.reply("POST /rest/api/3/search/jql", 200, searchResult(sprinted, sampleIssue("IS-2", "Done", 1), sampleIssue("IS-9", "Open", 0)))
+
+	var err error
+	out := captureStdout(t, func() { err = listFlow(f.config(), listOptions{Output: "keys"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "IS-2\nIS-9\nIS-10\n" {
+		t.Errorf("output = %q, want one key per line, no headers", out)
+	}
+
+	out = captureStdout(t, func() { err = listFlow(f.config(), listOptions{Output: "keys", Sort: "points:desc"}) })
+	if err != nil || out != "IS-10\nIS-2\nIS-9\n" {
+		t.Errorf("-sort points:desc: output = %q, err = %v", out, err)
+	}
+
+	if err := listFlow(f.config(), listOptions{Output: "keys", TSV: true}); !errors.Is(err, ErrUsage) {
+		t.Errorf("-o keys with -tsv: err = %v, want a usage error", err)
+	}
+}
+
+func TestColumnsHelpListsEveryColumn(t *testing.T) {
+	var lo listOptions
+	fs := newFlagSet("list")
+	lo.register(fs)
+	usage := fs.Lookup("columns").Usage
+	for _, c := range columns {
+		if !strings.Contains(usage, c.Name) {
+			t.Errorf("-columns help does not list %q: %s", c.Name, usage)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"strings"
+)
+
+// decimalSep is the decimal separator used by formatPoints; see
+// setLocale.
+var decimalSep = "."
+
+// commaDecimalLangs are languages that write 2.5 as "2,5".
+var commaDecimalLangs = map[string]bool{
+	"cs": true, "da": true, "de": true, "es": true, "fi": true, "fr": true,
+	"it": true, "nb": true, "nl": true, "pl": true, "pt": true, "ru": true,
+	"sv": true, "tr": true, "uk": true,
+}
+
+// envLocale returns the locale from LC_ALL, LC_NUMERIC or LANG, in that
+// order, or "en" when none is set.
+func envLocale() string {
+	for _, k := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		if v := os.Getenv(k); v != "" && v != "C" && v != "POSIX" {
+			return v
+		}
+	}
+	return "en"
+}
+
+// localeLang reduces "de_DE.UTF-8" or "de-AT" to "de".
+func localeLang(locale string) string {
+	lang, _, _ := strings.Cut(locale, ".")
+	lang, _, _ = strings.Cut(lang, "_")
+	lang, _, _ = strings.Cut(lang, "-")
+	return strings.ToLower(lang)
+}
+
+func setLocale(locale string) {
+	decimalSep = "."
+	if commaDecimalLangs[localeLang(locale)] {
+		decimalSep = ","
+	}
+}
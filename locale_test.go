@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestLocalePoints(t *testing.T) {
+	setGlobal(t, &decimalSep, decimalSep)
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"de", "2,5"},
+		{"de_DE.UTF-8", "2,5"},
+		{"fr-CA", "2,5"},
+		{"en_US.UTF-8", "2.5"},
+		{"C", "2.5"},
+	}
+	for _, tt := range tests {
+		setLocale(tt.locale)
+		if got := formatPoints(2.5); got != tt.want {
+			t.Errorf("locale %s: formatPoints(2.5) = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+	setLocale("de")
+	if got := formatPoints(3); got != "3" {
+		t.Errorf("de: formatPoints(3) = %q, want 3", got)
+	}
+}
@@ -3,59 +3,250 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"cmp"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"log"
+	"math"
+	"mime"
 	"net/http"
+	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 type JiraConfig struct {
 	Email string
 	URL   string
 	Token string
+
+	// DefaultProject prefixes bare numeric issue arguments, so "1234"
+	// becomes "PROJ-1234".
+	DefaultProject string
+
+	// PageSize is maxResults for each search request; see clampPageSize.
+	PageSize int
+
+	// AssigneeClause replaces "assignee = currentUser()" in the default
+	// query; from JIRA_ASSIGNEE_CLAUSE.
+	AssigneeClause string
+
+	// SearchPath is the preferred search endpoint, searchJQLPath or
+	// searchLegacyPath.
+	SearchPath string
+
+	// File is the optional config file; see configPath.
+	File fileConfig
+
+	// EpicLinkField is the classic Epic Link custom field, read where an
+	// issue has no parent; from JIRA_EPIC_LINK_FIELD.
+	EpicLinkField string
 }
 
 type Sprint struct {
-	ID    int    `json:"id"`
-	Name  string `json:"name"`
-	State string `json:"state"`
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
 }
 
 type IssueFields struct {
 	Summary   string `json:"summary"`
 	IssueType struct {
-		Name string `json:"name"`
+		Name    string `json:"name"`
+		Subtask bool   `json:"subtask"`
 	} `json:"issuetype"`
 	Status struct {
-		Name string `json:"name"`
+		Name     string `json:"name"`
+		Category struct {
+			Key string `json:"key"`
+		} `json:"statusCategory"`
 	} `json:"status"`
-	Points  float64  `json:"customfield_10004"`
-	Sprints []Sprint `json:"customfield_10007"`
+	Assignee *struct {
+		DisplayName string `json:"displayName"`
+	} `json:"assignee"`
+	Project struct {
+		Key string `json:"key"`
+	} `json:"project"`
+	Labels     []string     `json:"labels"`
+	Components []Component  `json:"components"`
+	Time       TimeTracking `json:"timetracking"`
+	Updated    string       `json:"updated"`
+	Points     float64      `json:"customfield_10004"`
+	Sprints    []Sprint     `json:"customfield_10007"`
+	Subtasks   []JiraIssue  `json:"subtasks"`
+
+	Parent *ParentIssue `json:"parent,omitempty"`
+
+	// Custom holds the raw values of the rawFields by ID.
+	Custom map[string]json.RawMessage `json:"-"`
 }
 
 type JiraIssue struct {
 	Key    string      `json:"key"`
 	Fields IssueFields `json:"fields"`
+
+	// Raw is the issue's JSON as Jira sent it, for -o jsonl.
+	Raw json.RawMessage `json:"-"`
+}
+
+func (ji *JiraIssue) UnmarshalJSON(b []byte) error {
+	type plain JiraIssue
+	if err := json.Unmarshal(b, (*plain)(ji)); err != nil {
+		return err
+	}
+	ji.Raw = append(json.RawMessage(nil), b...)
+	return nil
 }
 
 type Transition struct {
 	ID string `json:"id"`
 	To struct {
-		Name string `json:"name"`
+		Name     string `json:"name"`
+		Category struct {
+			Key string `json:"key"`
+		} `json:"statusCategory"`
 	} `json:"to"`
+	Fields map[string]TransitionField `json:"fields"`
+}
+
+// TransitionField is the screen metadata for a field shown during a
+// transition, returned when transitions.fields is expanded.
+type TransitionField struct {
+	Required      bool `json:"required"`
+	AllowedValues []struct {
+		Name string `json:"name"`
+	} `json:"allowedValues"`
+}
+
+type transitionOptions struct {
+	Resolution string
+
+	// Comment is added in the same request as the transition.
+	Comment string
 }
 
-func mustEnv(key string) string {
-	v := os.Getenv(key)
-	if v == "" {
-		log.Fatalf("missing env: %s", key)
+// verbose enables debugf output.
+var verbose bool
+
+func debugf(format string, a ...any) {
+	if verbose {
+		log.Printf(format, a...)
 	}
-	return v
+}
+
+// jiraTimeLayout is the timestamp format Jira uses in REST responses.
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+func parseJiraTime(s string) (time.Time, error) {
+	t, err := time.Parse(jiraTimeLayout, s)
+	if err != nil {
+		return time.Parse(time.RFC3339, s)
+	}
+	return t, nil
+}
+
+// tzOverride is the -tz flag; empty means use the Jira user's time zone.
+var tzOverride string
+
+// displayZone is resolved once by displayLocation.
+var displayZone *time.Location
+
+// displayLocation returns the zone timestamps are shown in: -tz if given,
+// else the time zone on the user's Jira profile, else the local zone.
+func displayLocation(cfg JiraConfig) (*time.Location, error) {
+	if displayZone != nil {
+		return displayZone, nil
+	}
+	name := tzOverride
+	if name == "" {
+		if me, err := getMyself(cfg); err == nil {
+			name = me.TimeZone
+		} else {
+			debugf("could not read time zone from profile: %v", err)
+		}
+	}
+	displayZone = time.Local
+	if name != "" {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, usageError("unknown time zone %q", name)
+		}
+		displayZone = loc
+	}
+	return displayZone, nil
+}
+
+func formatTime(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format("2006-01-02 15:04")
+}
+
+// mustSetting returns the environment variable env, or else the
+// top-level key of the config file, where `token = ${MY_JIRA_TOKEN}`
+// can pull from another variable. Having neither is fatal.
+func mustSetting(file fileConfig, env, key string) string {
+	if v := os.Getenv(env); v != "" {
+		return v
+	}
+	if v := file.Get("", key); v != "" {
+		return v
+	}
+	fail(usageError("missing env: %s (or %s in the config file)", env, key))
+	return ""
+}
+
+// expandKey qualifies a purely numeric issue argument with the default
+// project. Anything else is returned unchanged.
+func expandKey(cfg JiraConfig, arg string) string {
+	if cfg.DefaultProject == "" || arg == "" {
+		return arg
+	}
+	for _, r := range arg {
+		if r < '0' || r > '9' {
+			return arg
+		}
+	}
+	return cfg.DefaultProject + "-" + arg
+}
+
+// keyLess orders issue keys by project, then numerically by issue
+// number, so ABC-9 sorts before ABC-10.
+func keyLess(a, b string) bool {
+	pa, na, _ := strings.Cut(a, "-")
+	pb, nb, _ := strings.Cut(b, "-")
+	if pa != pb {
+		return pa < pb
+	}
+	ia, errA := strconv.Atoi(na)
+	ib, errB := strconv.Atoi(nb)
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return ia < ib
+}
+
+// apiURL builds an endpoint URL under cfg.URL. Joining goes through
+// net/url so a context path in the base (https://host/jira) is kept and
+// stray slashes don't double up. Parts are taken as already escaped; use
+// url.PathEscape for issue keys and other user input. query may be nil.
+func apiURL(cfg JiraConfig, query url.Values, parts ...string) string {
+	base, err := url.Parse(cfg.URL)
+	if err != nil {
+		// main rejects an unparsable JIRA_URL; this is only a fallback.
+		return cfg.URL + "/" + strings.Join(parts, "/")
+	}
+	u := base.JoinPath(parts...)
+	u.RawQuery = query.Encode()
+	return u.String()
 }
 
 func authHeader(cfg JiraConfig) string {
@@ -64,6 +255,9 @@ func authHeader(cfg JiraConfig) string {
 	return "Basic " + token
 }
 
+// httpClient is used for every request; -timeout sets its Timeout.
+var httpClient = &http.Client{Timeout: 30 * time.Second, CheckRedirect: checkRedirect}
+
 func doJSON(cfg JiraConfig, method, url string, body any, out any) error {
 	var r io.Reader
 	if body != nil {
@@ -74,45 +268,216 @@ func doJSON(cfg JiraConfig, method, url string, body any, out any) error {
 		r = bytes.NewReader(buf)
 	}
 
-	req, err := http.NewRequest(method, url, r)
+	req, err := http.NewRequestWithContext(rootCtx, method, url, r)
 	if err != nil {
 		return err
 	}
-
-	req.Header.Set("Authorization", authHeader(cfg))
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	return doRequest(cfg, req, out)
+}
+
+// send sends req with retries, reporting a failure to connect as
+// ErrNetwork.
+func send(req *http.Request) (*http.Response, error) {
+	res, err := sendWithRetry(req)
+	if err != nil {
+		if ctxErr := rootCtx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fmt.Errorf("%w: %v", ErrNetwork, err)
+	}
+	return res, nil
+}
+
+func checkStatus(res *http.Response) error {
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		err := &APIError{StatusCode: res.StatusCode, Status: res.Status, Hint: statusHint(res)}
+		if loc := res.Header.Get("Location"); res.StatusCode < 400 && loc != "" {
+			return fmt.Errorf("%w: redirect to %s not followed (-no-follow-redirects)", err, loc)
+		}
+		return err
+	}
+	return nil
+}
+
+// checkJSON rejects a response that isn't JSON, typically a proxy's
+// HTML login page served with 200, which would otherwise surface as a
+// JSON syntax error. A missing Content-Type is let through for empty
+// 204s.
+func checkJSON(res *http.Response) error {
+	ct := res.Header.Get("Content-Type")
+	if ct == "" {
+		return nil
+	}
+	mt, _, err := mime.ParseMediaType(ct)
+	if err == nil && (mt == "application/json" || strings.HasSuffix(mt, "+json")) {
+		return nil
+	}
+	return fmt.Errorf("expected JSON but got %s with status %s from %s (are your credentials valid?)", cmp.Or(mt, ct), res.Status, res.Request.URL.Redacted())
+}
+
+// doRequest authenticates and sends req, and decodes a JSON response
+// into out. Callers with a non-JSON body, like attachment uploads, build
+// the request themselves.
+func doRequest(cfg JiraConfig, req *http.Request, out any) error {
+	req.Header.Set("Authorization", authHeader(cfg))
 
-	res, err := http.DefaultClient.Do(req)
+	read := cacheable(req)
+	if read && cacheTTL > 0 && !noCache {
+		return cachedResponse(cfg, req, out)
+	}
+
+	res, err := send(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return fmt.Errorf("jira error: %d %s", res.StatusCode, res.Status)
+	if err := checkStatus(res); err != nil {
+		return err
+	}
+	if !read && cacheTTL > 0 {
+		clearResponseCache()
 	}
 
 	if out != nil {
-		return json.NewDecoder(res.Body).Decode(out)
+		if err := checkJSON(res); err != nil {
+			return err
+		}
+		err := json.NewDecoder(res.Body).Decode(out)
+		if err == io.EOF {
+			// Empty body, e.g. 204 No Content.
+			return nil
+		}
+		return err
 	}
 
 	return nil
 }
 
 func getIssues(cfg JiraConfig) ([]JiraIssue, error) {
-	var out struct {
-		Issues []JiraIssue `json:"issues"`
-	}
+	return searchIssues(cfg, issueQuery{Assignee: cfg.AssigneeClause}.JQL())
+}
+
+// Jira Cloud is migrating from the legacy search endpoint to search/jql;
+// tenants may have only one of them. searchIssues tries the configured
+// one first and falls back to the other on 404/410.
+const (
+	searchJQLPath    = "/rest/api/3/search/jql"
+	searchLegacyPath = "/rest/api/3/search"
+)
+
+func endpointGone(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusGone)
+}
 
+// searchPage is one page of results from either search endpoint:
+// search/jql pages with nextPageToken/isLast, legacy search with
+// startAt/total.
+type searchPage struct {
+	Issues        []JiraIssue `json:"issues"`
+	NextPageToken string      `json:"nextPageToken"`
+	IsLast        bool        `json:"isLast"`
+	Total         int         `json:"total"`
+}
+
+// searchFields are the issue fields every search requests.
+var searchFields = []string{"summary", "customfield_10004", "issuetype", "status", "customfield_10007", "assignee", "labels", "components", "updated", "timetracking"}
+
+// searchPageBody builds the request for the page after the len(seen)
+// issues fetched so far.
+func searchPageBody(path, jql string, pageSize int, seen int, token string) map[string]any {
 	body := map[string]any{
-		"jql":    "assignee = currentUser() AND statusCategory != Done AND issuetype != Epic",
-		"fields": []string{"summary", "customfield_10004", "issuetype", "status", "customfield_10007"},
+		"jql":        jql,
+		"fields":     searchFields,
+		"maxResults": pageSize,
+	}
+	if path == searchLegacyPath {
+		body["startAt"] = seen
+	} else if token != "" {
+		body["nextPageToken"] = token
+	}
+	return body
+}
+
+// lastPage reports whether page ends the result set for path.
+func lastPage(path string, page searchPage, seen int) bool {
+	if len(page.Issues) == 0 {
+		return true
+	}
+	if path == searchLegacyPath {
+		return seen >= page.Total
+	}
+	return page.IsLast || page.NextPageToken == ""
+}
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 100
+)
+
+// clampPageSize keeps n within what Jira Cloud accepts, treating
+// anything non-positive as the default.
+func clampPageSize(n int) int {
+	if n <= 0 {
+		return defaultPageSize
+	}
+	return min(n, maxPageSize)
+}
+
+func searchIssues(cfg JiraConfig, jql string) ([]JiraIssue, error) {
+	issues := []JiraIssue{}
+	err := searchIssuesEach(cfg, jql, func(page []JiraIssue) error {
+		issues = append(issues, page...)
+		return nil
+	})
+	return issues, err
+}
+
+// searchIssuesEach runs jql and hands each page of results to fn as it
+// arrives, stopping early if fn returns an error.
+func searchIssuesEach(cfg JiraConfig, jql string, fn func([]JiraIssue) error) error {
+	path, alternate := searchJQLPath, searchLegacyPath
+	if cfg.SearchPath == searchLegacyPath {
+		path, alternate = alternate, path
 	}
+	pageSize := clampPageSize(cfg.PageSize)
+
+	seen := 0
+	token := ""
+	prog := newProgress()
+	defer prog.done()
+	defer atInterrupt(prog.done)()
+	for {
+		prog.next(seen)
+		var page searchPage
+		err := doJSON(cfg, http.MethodPost, apiURL(cfg, nil, path), searchPageBody(path, jql, pageSize, seen, token), &page)
+		if seen == 0 && endpointGone(err) {
+			debugf("%s returned %v, retrying with %s", path, err, alternate)
+			path = alternate
+			err = doJSON(cfg, http.MethodPost, apiURL(cfg, nil, path), searchPageBody(path, jql, pageSize, 0, ""), &page)
+		}
+		if err != nil {
+			return err
+		}
 
-	err := doJSON(cfg, http.MethodPost, cfg.URL+"/rest/api/3/search/jql", body, &out)
-	return out.Issues, err
+		// Some instances send "issues": null for an empty result.
+		seen += len(page.Issues)
+		// Clear the spinner so fn's output starts on a clean line.
+		prog.done()
+		if err := fn(page.Issues); err != nil {
+			return err
+		}
+		// A repeated token would loop forever; treat it as the end.
+		if lastPage(path, page, seen) || (token != "" && page.NextPageToken == token) {
+			return nil
+		}
+		token = page.NextPageToken
+	}
 }
 
 func getTransitions(cfg JiraConfig, issueKey string) ([]Transition, error) {
@@ -120,127 +485,962 @@ func getTransitions(cfg JiraConfig, issueKey string) ([]Transition, error) {
 		Transitions []Transition `json:"transitions"`
 	}
 
-	url := fmt.Sprintf("%s/rest/api/3/issue/%s/transitions", cfg.URL, issueKey)
-	err := doJSON(cfg, http.MethodGet, url, nil, &out)
+	u := apiURL(cfg, url.Values{"expand": {"transitions.fields"}}, "rest/api/3/issue", url.PathEscape(issueKey), "transitions")
+	err := doJSON(cfg, http.MethodGet, u, nil, &out)
 	return out.Transitions, err
 }
 
-func transitionIssue(cfg JiraConfig, issueKey, targetStatus string) error {
-	transitions, err := getTransitions(cfg, issueKey)
-	if err != nil {
-		return err
-	}
+// transitionBody builds the POST body for performing t, checking opts
+// against the fields the transition screen accepts.
+func transitionBody(t *Transition, opts transitionOptions) (map[string]any, error) {
+	body := map[string]any{
+		"transition": map[string]any{"id": t.ID},
+	}
+	if opts.Comment != "" {
+		body["update"] = map[string]any{
+			"comment": []any{
+				map[string]any{"add": map[string]any{"body": textToADF(opts.Comment)}},
+			},
+		}
+	}
+
+	res, hasRes := t.Fields["resolution"]
+	allowed := make([]string, len(res.AllowedValues))
+	for i, v := range res.AllowedValues {
+		allowed[i] = v.Name
+	}
+
+	if opts.Resolution == "" {
+		if res.Required {
+			return nil, usageError("transition to %q requires -resolution (allowed: %s)", t.To.Name, strings.Join(allowed, ", "))
+		}
+		return body, nil
+	}
+
+	if !hasRes {
+		return nil, usageError("transition to %q does not accept a resolution", t.To.Name)
+	}
+	name := opts.Resolution
+	if len(allowed) > 0 {
+		name = ""
+		for _, a := range allowed {
+			if strings.EqualFold(a, opts.Resolution) {
+				name = a
+				break
+			}
+		}
+		if name == "" {
+			return nil, usageError("resolution %q not allowed for %q (allowed: %s)", opts.Resolution, t.To.Name, strings.Join(allowed, ", "))
+		}
+	}
+	body["fields"] = map[string]any{
+		"resolution": map[string]any{"name": name},
+	}
+	return body, nil
+}
+
+// normalizeStatus folds case, treats hyphens and underscores as spaces
+// and collapses runs of whitespace, so "in-progress" and " In  Progress"
+// compare equal.
+func normalizeStatus(s string) string {
+	s = strings.NewReplacer("-", " ", "_", " ").Replace(strings.ToLower(s))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// matchTransition finds the transition to target, preferring an exact
+// (case-insensitive) match over a normalized one.
+func matchTransition(transitions []Transition, target string) *Transition {
+	target = strings.Join(strings.Fields(target), " ")
+	for i := range transitions {
+		if strings.EqualFold(transitions[i].To.Name, target) {
+			return &transitions[i]
+		}
+	}
+	norm := normalizeStatus(target)
+	for i := range transitions {
+		if normalizeStatus(transitions[i].To.Name) == norm {
+			return &transitions[i]
+		}
+	}
+	return nil
+}
+
+// transitionIssue moves issueKey to targetStatus and returns the
+// transition it took.
+func transitionIssue(cfg JiraConfig, issueKey, targetStatus string, opts transitionOptions) (*Transition, error) {
+	transitions, err := getTransitions(cfg, issueKey)
+	if err != nil {
+		return nil, err
+	}
+
+	match := matchTransition(transitions, targetStatus)
+	if match == nil {
+		names := make([]string, len(transitions))
+		for i, t := range transitions {
+			names[i] = t.To.Name
+		}
+		return nil, fmt.Errorf("no transition to %q for issue %s (available: %s)", targetStatus, issueKey, strings.Join(names, ", "))
+	}
+
+	body, err := transitionBody(match, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	u := apiURL(cfg, nil, "rest/api/3/issue", url.PathEscape(issueKey), "transitions")
+	return match, doJSON(cfg, http.MethodPost, u, body, nil)
+}
+
+// backlogOnDone is -backlog-on-done, or backlog_on_done in the config
+// file: take issues out of their sprint once a transition lands them in
+// the Done category.
+var backlogOnDone bool
+
+// backlogIfDone moves issueKey to the backlog after t if backlogOnDone
+// is set and t ended in the Done category, and reports whether it did.
+func backlogIfDone(cfg JiraConfig, issueKey string, t *Transition) (bool, error) {
+	if !backlogOnDone || t.To.Category.Key != "done" {
+		return false, nil
+	}
+	if err := moveIssuesToBacklog(cfg, []string{issueKey}); err != nil {
+		return false, fmt.Errorf("transitioned %s, but moving it to the backlog failed: %w", issueKey, err)
+	}
+	fmt.Printf("Moved %s to the backlog\n", issueKey)
+	return true, nil
+}
+
+// stdin is where commands read piped input from.
+var stdin io.Reader = os.Stdin
+
+// readKeys reads newline-separated issue keys, skipping blank lines.
+func readKeys(cfg JiraConfig, r io.Reader) ([]string, error) {
+	var keys []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if k := strings.TrimSpace(sc.Text()); k != "" {
+			keys = append(keys, expandKey(cfg, k))
+		}
+	}
+	return keys, sc.Err()
+}
+
+func transitionCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("transition")
+	var opts transitionOptions
+	fs.StringVar(&opts.Resolution, "resolution", "", "resolution to set, e.g. Done or Won't Do")
+	var comment optionalString
+	fs.Var(&comment, "comment", "add this comment along with the transition")
+	fs.BoolVar(&failFast, "fail-fast", failFast, "stop at the first failing issue")
+	fs.StringVar(&resultsPath, "results", resultsPath, "record each key's outcome in this JSON file (stdin batches)")
+	fs.StringVar(&resumePath, "resume", resumePath, "skip keys that succeeded in this results file, and update it")
+	fs.BoolVar(&openAfter, "open-after", openAfter, "open the issue in a browser afterwards (single key only)")
+	fs.BoolVar(&backlogOnDone, "backlog-on-done", backlogOnDone, "move issues to the backlog when the transition lands in the Done category")
+	var from stringList
+	fs.Var(&from, "from", "only transition issues currently in this status (repeatable or comma-separated)")
+	fromBranch := fs.Bool("branch", false, "take the issue key from the current git branch")
+	pos := parseArgs(fs, args)
+	if *fromBranch {
+		// Everything after -branch is the status; a key as well would be
+		// read as part of it.
+		if len(pos) > 0 && (pos[0] == "-" || findIssueKey(strings.ToUpper(pos[0])) == strings.ToUpper(pos[0])) {
+			return usageError("-branch does not take an issue key")
+		}
+		k, err := branchKey(cfg)
+		if err != nil {
+			return err
+		}
+		pos = append([]string{k}, pos...)
+	}
+	if comment.Given {
+		opts.Comment = strings.TrimSpace(comment.Value)
+		if opts.Comment == "" {
+			return usageError("-comment text cannot be empty")
+		}
+	}
+	if len(pos) == 0 {
+		return usageError("usage: jira-cli transition <KEY|-|-branch> <status>")
+	}
+
+	status := strings.TrimSpace(strings.Join(pos[1:], " "))
+	if status == "" {
+		return usageError("missing target status")
+	}
+	status = resolveStatusAlias(cfg.File, status)
+
+	// run reports whether k was transitioned; a -from mismatch is a
+	// skip, not an error.
+	run := func(k string) (bool, error) {
+		if len(from) > 0 {
+			ji, err := getIssue(cfg, k, "status")
+			if err != nil {
+				return false, err
+			}
+			if !statusIn(ji.Fields.Status.Name, from) {
+				fmt.Printf("Skipped %s: status is %q, not %s\n", k, ji.Fields.Status.Name, strings.Join(from, " or "))
+				return false, nil
+			}
+		}
+		t, err := transitionIssue(cfg, k, status, opts)
+		if err != nil {
+			return false, err
+		}
+		fmt.Printf("Transitioned %s to %q\n", k, status)
+		_, err = backlogIfDone(cfg, k, t)
+		return true, err
+	}
+
+	if pos[0] != "-" {
+		issueKey := expandKey(cfg, pos[0])
+		done, err := run(issueKey)
+		if done && openAfter {
+			openIssue(cfg, issueKey)
+		}
+		return err
+	}
+
+	keys, err := readKeys(cfg, stdin)
+	if err != nil {
+		return err
+	}
+	return runBatch(keys, func(k string) error {
+		_, err := run(k)
+		return err
+	})
+}
+
+// statusIn reports whether status matches any of allowed, using the same
+// normalization as transition targets.
+func statusIn(status string, allowed []string) bool {
+	for _, a := range allowed {
+		if normalizeStatus(a) == normalizeStatus(status) {
+			return true
+		}
+	}
+	return false
+}
+
+func addIssueToSprint(cfg JiraConfig, sprintID int, issueKey string) error {
+	return addIssuesToSprint(cfg, sprintID, []string{issueKey})
+}
+
+func addIssuesToSprint(cfg JiraConfig, sprintID int, issueKeys []string) error {
+	u := apiURL(cfg, nil, "rest/agile/1.0/sprint", strconv.Itoa(sprintID), "issue")
+	return inChunks(issueKeys, func(chunk []string) error {
+		return doJSON(cfg, http.MethodPost, u, map[string]any{"issues": chunk}, nil)
+	})
+}
+
+// moveIssuesToBacklog removes the issues from whatever sprint they are in.
+func moveIssuesToBacklog(cfg JiraConfig, issueKeys []string) error {
+	u := apiURL(cfg, nil, "rest/agile/1.0/backlog/issue")
+	return inChunks(issueKeys, func(chunk []string) error {
+		return doJSON(cfg, http.MethodPost, u, map[string]any{"issues": chunk}, nil)
+	})
+}
+
+func backlogCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("backlog")
+	yes := fs.Bool("yes", false, "don't ask for confirmation")
+	fs.BoolVar(&skipPreflight, "skip-preflight", skipPreflight, "don't check permissions first")
+	fs.IntVar(&batchSize, "batch-size", batchSize, "move at most this many issues per request")
+	fs.BoolVar(&failFast, "fail-fast", failFast, "stop at the first failing batch")
+	pos := parseArgs(fs, args)
+	if len(pos) == 0 {
+		return usageError("usage: jira-cli backlog <KEY>...")
+	}
+
+	keys := make([]string, len(pos))
+	for i, k := range pos {
+		keys[i] = expandKey(cfg, k)
+	}
+	if len(keys) > confirmThreshold && !*yes {
+		if !confirm(fmt.Sprintf("Move %d issues to the backlog?", len(keys))) {
+			return nil
+		}
+	}
+
+	if err := checkPermissions(cfg, keys[0], sprintPermissions...); err != nil {
+		return err
+	}
+	if err := moveIssuesToBacklog(cfg, keys); err != nil {
+		return err
+	}
+	fmt.Printf("Moved %s to the backlog\n", strings.Join(keys, ", "))
+	return nil
+}
+
+// noSprintFallback is -no-active-sprint-fallback: sprint moves must name
+// their sprint rather than take the first active one among your issues,
+// which is a guess when the issues span boards.
+var noSprintFallback bool
+
+var errNoSprintFallback = usageError("no sprint given and -no-active-sprint-fallback is set; pass move -sprint-id")
+
+func findActiveSprint(issues []JiraIssue) (*Sprint, error) {
+	if noSprintFallback {
+		return nil, errNoSprintFallback
+	}
+	active := activeSprints(issues)
+	switch len(active) {
+	case 0:
+		return nil, fmt.Errorf("no active sprint found in current issues")
+	case 1:
+		return &active[0], nil
+	}
+
+	// Issues on several boards can each be in their board's active
+	// sprint; picking the first would be a coin toss.
+	names := make([]string, len(active))
+	for i, sp := range active {
+		names[i] = fmt.Sprintf("%s (id %d)", sp.Name, sp.ID)
+	}
+	if !promptIsTerminal() {
+		return nil, usageError("your issues are in %d active sprints: %s; pass move -sprint-id", len(active), strings.Join(names, ", "))
+	}
+	i, err := pickFromList("Select sprint", names)
+	if err != nil {
+		return nil, err
+	}
+	if i == -1 {
+		return nil, errors.New("no sprint selected")
+	}
+	return &active[i], nil
+}
+
+// activeSprints returns the distinct active sprints of issues, in the
+// order they first appear.
+func activeSprints(issues []JiraIssue) []Sprint {
+	var out []Sprint
+	seen := map[int]bool{}
+	for _, ji := range issues {
+		for _, sp := range ji.Fields.Sprints {
+			if strings.EqualFold(sp.State, "active") && !seen[sp.ID] {
+				seen[sp.ID] = true
+				out = append(out, sp)
+			}
+		}
+	}
+	return out
+}
+
+type Board struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func getBoards(cfg JiraConfig, projectKey string) ([]Board, error) {
+	var boards []Board
+	for {
+		var page struct {
+			Values []Board `json:"values"`
+			IsLast bool    `json:"isLast"`
+		}
+		q := url.Values{"projectKeyOrId": {projectKey}, "startAt": {strconv.Itoa(len(boards))}}
+		if err := doJSON(cfg, http.MethodGet, apiURL(cfg, q, "rest/agile/1.0/board"), nil, &page); err != nil {
+			return nil, err
+		}
+		boards = append(boards, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			return boards, nil
+		}
+	}
+}
+
+func getBoardSprints(cfg JiraConfig, boardID int) ([]Sprint, error) {
+	var sprints []Sprint
+	for {
+		var page struct {
+			Values []Sprint `json:"values"`
+			IsLast bool     `json:"isLast"`
+		}
+		q := url.Values{"state": {"active,future"}, "startAt": {strconv.Itoa(len(sprints))}}
+		u := apiURL(cfg, q, "rest/agile/1.0/board", strconv.Itoa(boardID), "sprint")
+		if err := doJSON(cfg, http.MethodGet, u, nil, &page); err != nil {
+			return nil, err
+		}
+		sprints = append(sprints, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			return sprints, nil
+		}
+	}
+}
+
+// findSprintByName looks for an active or future sprint with the given
+// name on any of the project's boards.
+func findSprintByName(cfg JiraConfig, projectKey, name string) (*Sprint, error) {
+	boards, err := getBoards(cfg, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range boards {
+		sprints, err := getBoardSprints(cfg, b.ID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range sprints {
+			if strings.EqualFold(sprints[i].Name, name) {
+				return &sprints[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: no open sprint named %q in project %s", ErrNotFound, name, projectKey)
+}
+
+func moveIssueToCurrentSprint(cfg JiraConfig, issueKey string) error {
+	issues, err := getIssues(cfg)
+	if err != nil {
+		return err
+	}
+
+	s, err := findActiveSprint(issues)
+	if err != nil {
+		return err
+	}
+
+	return addIssueToSprint(cfg, s.ID, issueKey)
+}
+
+// sprintCache holds sprints fetched by getSprint for the life of the process.
+var sprintCache = map[int]Sprint{}
+
+func getSprint(cfg JiraConfig, id int) (Sprint, error) {
+	if sp, ok := sprintCache[id]; ok {
+		return sp, nil
+	}
+	var sp Sprint
+	if err := doJSON(cfg, http.MethodGet, apiURL(cfg, nil, "rest/agile/1.0/sprint", strconv.Itoa(id)), nil, &sp); err != nil {
+		return Sprint{}, err
+	}
+	sprintCache[id] = sp
+	return sp, nil
+}
+
+// fillSprintDates looks up start/end dates for sprints on issues that
+// came back without them. Lookup failures just leave the dates empty.
+func fillSprintDates(cfg JiraConfig, issues []JiraIssue) {
+	for i := range issues {
+		sprints := issues[i].Fields.Sprints
+		for j := range sprints {
+			if sprints[j].StartDate != "" && sprints[j].EndDate != "" {
+				continue
+			}
+			if sp, err := getSprint(cfg, sprints[j].ID); err == nil {
+				sprints[j].StartDate = cmp.Or(sprints[j].StartDate, sp.StartDate)
+				sprints[j].EndDate = cmp.Or(sprints[j].EndDate, sp.EndDate)
+			}
+		}
+	}
+}
+
+// currentSprint picks the sprint an issue is grouped under: the active
+// one, else the one that ends last. Issues carried over several closed
+// sprints would otherwise show whichever happens to be last in the array.
+// It returns nil for backlog issues.
+func currentSprint(s []Sprint) *Sprint {
+	var best *Sprint
+	for i := range s {
+		sp := &s[i]
+		if sp.State == "active" {
+			return sp
+		}
+		// Ties, such as sprints without dates, keep array order.
+		if best == nil || !sprintEnd(*sp).Before(sprintEnd(*best)) {
+			best = sp
+		}
+	}
+	return best
+}
+
+// sprintEnd is the end date currentSprint orders by. An undated future
+// sprint sorts after every dated one and an undated closed one before.
+func sprintEnd(sp Sprint) time.Time {
+	if t, err := parseJiraTime(sp.EndDate); err == nil {
+		return t
+	}
+	if sp.State == "future" {
+		return time.Unix(1<<62, 0)
+	}
+	return time.Time{}
+}
+
+func sprintName(s []Sprint) string {
+	sp := currentSprint(s)
+	if sp == nil {
+		return "Backlog"
+	}
+	return sp.Name
+}
+
+// sprintRange formats a sprint's dates as "Jan 5–Jan 19", or "" when
+// either date is missing.
+func sprintRange(sp *Sprint) string {
+	if sp == nil {
+		return ""
+	}
+	start, err := parseJiraTime(sp.StartDate)
+	if err != nil {
+		return ""
+	}
+	end, err := parseJiraTime(sp.EndDate)
+	if err != nil {
+		return ""
+	}
+	return start.Format("Jan 2") + "–" + end.Format("Jan 2")
+}
+
+// formatPoints shows whole numbers as-is and anything else to one
+// decimal place, using the locale's decimal separator.
+func formatPoints(p float64) string {
+	if p == 0 {
+		return "-"
+	}
+	s := strconv.FormatFloat(math.Round(p*10)/10, 'f', -1, 64)
+	return strings.Replace(s, ".", decimalSep, 1)
+}
+
+type formatOptions struct {
+	// LinkBase, when set, renders issue keys as OSC 8 hyperlinks to
+	// LinkBase/browse/KEY.
+	LinkBase string
+
+	// Columns lists the issue columns to show, in order. Empty means
+	// defaultColumns.
+	Columns []string
+
+	// PointBars draws each issue's points as a bar relative to the
+	// largest issue in its sprint.
+	PointBars bool
+
+	// Padded aligns columns with spaces instead of tabs.
+	Padded bool
+
+	// Compact prints only the per-sprint header lines.
+	Compact bool
+
+	// LabelColors maps lowercased labels to ANSI color codes for the
+	// issue's row. LabelPriority sorts issues with lower-numbered labels
+	// to the top of their sprint.
+	LabelColors   map[string]string
+	LabelPriority map[string]int
+
+	// StaleBefore, when set, marks issues last updated before it.
+	StaleBefore time.Time
+
+	// Theme, set when color is on, colors the rows of stale issues and
+	// those without a label color by status category.
+	Theme *theme
+
+	// Time swaps points for time tracking in sprint headers: the sum of
+	// remaining estimates instead of points.
+	Time bool
+
+	// PointsProgress adds the points of Done-category issues to sprint
+	// headers, e.g. "8 pts, 3 done".
+	PointsProgress bool
+
+	// Wrap, when positive, wraps summaries to fit this many columns,
+	// continuing them on indented lines of their own.
+	Wrap int
+
+	// GroupClosedAs, when set, lists every issue whose current sprint is
+	// closed under one group of that name.
+	GroupClosedAs string
+
+	// EpicNames names classic epic links by key for the epic column;
+	// see epicNames.
+	EpicNames map[string]string
+
+	// maxPoints is the largest issue in the sprint being rendered.
+	maxPoints float64
+}
+
+const pointBarWidth = 4
+
+// pointBar renders p as a bar of pointBarWidth cells scaled to max.
+// Any non-zero value gets at least one filled cell.
+func pointBar(p, max float64) string {
+	filled := 0
+	if max > 0 {
+		filled = int(p/max*pointBarWidth + 0.5)
+	}
+	if p > 0 && filled == 0 {
+		filled = 1
+	}
+	if filled > pointBarWidth {
+		filled = pointBarWidth
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", pointBarWidth-filled)
+}
+
+func formatPointsColumn(ji JiraIssue, opts formatOptions) string {
+	p := formatPoints(ji.Fields.Points)
+	if !opts.PointBars || opts.maxPoints == 0 {
+		return p
+	}
+	return pointBar(ji.Fields.Points, opts.maxPoints) + " " + p
+}
+
+type column struct {
+	Name  string
+	Value func(ji JiraIssue, opts formatOptions) string
+}
+
+var columns = []column{
+	{"key", func(ji JiraIssue, opts formatOptions) string { return formatKey(ji.Key, opts) }},
+	{"points", formatPointsColumn},
+	{"status", func(ji JiraIssue, _ formatOptions) string { return ji.Fields.Status.Name }},
+	{"type", func(ji JiraIssue, _ formatOptions) string { return ji.Fields.IssueType.Name }},
+	{"summary", formatSummary},
+	{"sprint", func(ji JiraIssue, _ formatOptions) string { return sprintName(ji.Fields.Sprints) }},
+	{"estimate", func(ji JiraIssue, _ formatOptions) string { return orDash(ji.Fields.Time.OriginalEstimate) }},
+	{"remaining", func(ji JiraIssue, _ formatOptions) string { return orDash(ji.Fields.Time.RemainingEstimate) }},
+	{"spent", func(ji JiraIssue, _ formatOptions) string { return orDash(ji.Fields.Time.TimeSpent) }},
+	{"components", func(ji JiraIssue, _ formatOptions) string { return orDash(componentNames(ji.Fields.Components)) }},
+	{"epic", formatEpic},
+	{"assignee", func(ji JiraIssue, _ formatOptions) string {
+		if ji.Fields.Assignee == nil || ji.Fields.Assignee.DisplayName == "" {
+			return "-"
+		}
+		return ji.Fields.Assignee.DisplayName
+	}},
+}
+
+// epicMarker prefixes the summary of epics, which only show up with
+// -include-epics or a custom -jql.
+const epicMarker = "◆ "
+
+func formatSummary(ji JiraIssue, opts formatOptions) string {
+	s := ji.Fields.Summary
+	if strings.EqualFold(ji.Fields.IssueType.Name, "Epic") {
+		s = epicMarker + s
+	}
+	if isStale(ji, opts.StaleBefore) {
+		s = staleMarker + s
+	}
+	return s
+}
+
+var defaultColumns = []string{"key", "points", "status", "type", "summary"}
 
-	var match *Transition
-	for i := range transitions {
-		if strings.EqualFold(transitions[i].To.Name, targetStatus) {
-			match = &transitions[i]
-			break
+func lookupColumn(name string) *column {
+	for i := range columns {
+		if strings.EqualFold(columns[i].Name, name) {
+			return &columns[i]
 		}
 	}
-	if match == nil {
-		names := make([]string, len(transitions))
-		for i, t := range transitions {
-			names[i] = t.To.Name
+	return nil
+}
+
+// parseColumns parses a comma-separated -columns value.
+func parseColumns(spec string) ([]string, error) {
+	var names []string
+	for _, n := range strings.Split(spec, ",") {
+		n = strings.TrimSpace(n)
+		if n == "" {
+			continue
+		}
+		c := lookupColumn(n)
+		if c == nil {
+			valid := make([]string, len(columns))
+			for i, c := range columns {
+				valid[i] = c.Name
+			}
+			return nil, usageError("unknown column %q (valid: %s)", n, strings.Join(valid, ", "))
 		}
-		return fmt.Errorf("no transition to %q for issue %s (available: %s)", targetStatus, issueKey, strings.Join(names, ", "))
+		names = append(names, c.Name)
+	}
+	if len(names) == 0 {
+		return nil, usageError("-columns must name at least one column")
 	}
+	return names, nil
+}
 
-	body := map[string]any{
-		"transition": map[string]any{"id": match.ID},
+func rowCells(ji JiraIssue, opts formatOptions) []string {
+	names := opts.Columns
+	if len(names) == 0 {
+		names = defaultColumns
 	}
+	cells := make([]string, len(names))
+	for i, n := range names {
+		cells[i] = lookupColumn(n).Value(ji, opts)
+	}
+	return cells
+}
 
-	url := fmt.Sprintf("%s/rest/api/3/issue/%s/transitions", cfg.URL, issueKey)
-	return doJSON(cfg, http.MethodPost, url, body, nil)
+// visibleWidth is the number of terminal cells s occupies, ignoring
+// ANSI color and OSC 8 hyperlink escapes.
+func visibleWidth(s string) int {
+	n := 0
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' && i+1 < len(s) {
+			switch s[i+1] {
+			case '[':
+				j := strings.IndexFunc(s[i+2:], func(r rune) bool { return r >= 0x40 && r <= 0x7e })
+				if j >= 0 {
+					i += j + 3
+					continue
+				}
+			case ']':
+				j := strings.Index(s[i+2:], "\x1b\\")
+				if j >= 0 {
+					i += j + 4
+					continue
+				}
+			}
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		n++
+	}
+	return n
 }
 
-func addIssueToSprint(cfg JiraConfig, sprintID int, issueKey string) error {
-	body := map[string]any{
-		"issues": []string{issueKey},
+// joinPadded pads every cell but the last to its column width.
+func joinPadded(cells []string, widths []int) string {
+	var b strings.Builder
+	for i, c := range cells {
+		b.WriteString(c)
+		if i < len(cells)-1 {
+			b.WriteString(strings.Repeat(" ", widths[i]-visibleWidth(c)+2))
+		}
 	}
-	url := fmt.Sprintf("%s/rest/agile/1.0/sprint/%d/issue", cfg.URL, sprintID)
-	return doJSON(cfg, http.MethodPost, url, body, nil)
+	return b.String()
 }
 
-func findActiveSprint(issues []JiraIssue) (*Sprint, error) {
-	for _, ji := range issues {
-		for i := range ji.Fields.Sprints {
-			sp := ji.Fields.Sprints[i]
-			if strings.EqualFold(sp.State, "active") {
-				s := sp
-				return &s, nil
+func columnWidths(rows [][]string) []int {
+	var widths []int
+	for _, row := range rows {
+		for i, c := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
 			}
+			widths[i] = max(widths[i], visibleWidth(c))
 		}
 	}
-	return nil, fmt.Errorf("no active sprint found in current issues")
+	return widths
 }
 
-func moveIssueToCurrentSprint(cfg JiraConfig, issueKey string) error {
-	issues, err := getIssues(cfg)
-	if err != nil {
-		return err
+// formatTSV renders a header row and one tab-separated row per issue,
+// with tabs and newlines in values replaced by spaces.
+func formatTSV(issues []JiraIssue, opts formatOptions) string {
+	names := opts.Columns
+	if len(names) == 0 {
+		names = defaultColumns
 	}
+	clean := strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+	lines := []string{strings.Join(names, "\t")}
+	for _, ji := range issues {
+		cells := rowCells(ji, opts)
+		for i := range cells {
+			cells[i] = clean.Replace(cells[i])
+		}
+		lines = append(lines, strings.Join(cells, "\t"))
+	}
+	return strings.Join(lines, "\n")
+}
 
-	s, err := findActiveSprint(issues)
-	if err != nil {
-		return err
+// formatFlat renders every issue in a single table under one header row,
+// without sprint grouping.
+func formatFlat(issues []JiraIssue, opts formatOptions) string {
+	if len(issues) == 0 {
+		return noIssuesMessage
+	}
+	names := opts.Columns
+	if len(names) == 0 {
+		names = defaultColumns
 	}
 
-	return addIssueToSprint(cfg, s.ID, issueKey)
-}
+	header := make([]string, len(names))
+	for i, n := range names {
+		header[i] = strings.ToUpper(n)
+	}
+	for _, ji := range issues {
+		opts.maxPoints = max(opts.maxPoints, ji.Fields.Points)
+	}
 
-func sprintName(s []Sprint) string {
-	if len(s) == 0 {
-		return "Backlog"
+	rows := [][]string{header}
+	for _, ji := range issues {
+		rows = append(rows, rowCells(ji, opts))
 	}
-	for _, sp := range s {
-		if sp.State == "active" {
-			return sp.Name
+	var widths []int
+	if opts.Padded {
+		widths = columnWidths(rows)
+	}
+	more := make([][]string, len(rows))
+	if opts.Wrap > 0 {
+		for i := 1; i < len(rows); i++ {
+			more[i] = wrapSummary(rows[i], widths, 0, opts)
+		}
+		if opts.Padded {
+			widths = columnWidths(rows)
+		}
+	}
+
+	var lines []string
+	for i, r := range rows {
+		line := strings.Join(r, "\t")
+		if opts.Padded {
+			line = joinPadded(r, widths)
+		}
+		color := ""
+		if i > 0 {
+			color = rowColor(issues[i-1], opts)
+		}
+		lines = append(lines, colorize(color, line))
+		for _, m := range more[i] {
+			lines = append(lines, colorize(color, m))
 		}
 	}
-	return s[len(s)-1].Name
+	return strings.Join(lines, "\n")
 }
 
-func formatPoints(p float64) string {
-	if p == 0 {
-		return "-"
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// hyperlink wraps text in an OSC 8 escape. The escape is zero-width, so
+// tab stops after it still line up.
+func hyperlink(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+func formatKey(key string, opts formatOptions) string {
+	if opts.LinkBase == "" {
+		return key
 	}
-	return strconv.Itoa(int(p + 0.5))
+	return hyperlink(opts.LinkBase+"/browse/"+key, key)
 }
 
-func formatIssuesBySprint(issues []JiraIssue) string {
+const noIssuesMessage = "No issues found"
+
+func formatIssuesBySprint(issues []JiraIssue, opts formatOptions) string {
+	if len(issues) == 0 {
+		return noIssuesMessage
+	}
+
 	groups := map[string][]JiraIssue{}
+	ranges := map[string]string{}
+	active := map[string]bool{}
+	var order []string
 
 	for _, ji := range issues {
+		sp := currentSprint(ji.Fields.Sprints)
 		n := sprintName(ji.Fields.Sprints)
+		bucketed := opts.GroupClosedAs != "" && sp != nil && sp.State == "closed"
+		if bucketed {
+			n = opts.GroupClosedAs
+		}
+		if _, ok := groups[n]; !ok {
+			order = append(order, n)
+		}
 		groups[n] = append(groups[n], ji)
+		if sp != nil && sp.State == "active" {
+			active[n] = true
+		}
+		if r := sprintRange(sp); r != "" && !bucketed {
+			ranges[n] = r
+		}
 	}
 
-	var lines []string
-	for sprint, list := range groups {
-		var total float64
+	// Active sprints first, then other sprints as they first appear,
+	// then the backlog.
+	rank := func(n string) int {
+		switch {
+		case active[n]:
+			return 0
+		case n == "Backlog":
+			return 2
+		}
+		return 1
+	}
+	sort.SliceStable(order, func(i, j int) bool { return rank(order[i]) < rank(order[j]) })
+
+	type row struct {
+		header string
+		cells  []string
+		color  string
+		more   []string // wrapped summary lines
+	}
+	var rows []row
+	var cells [][]string
+	for _, sprint := range order {
+		list := groups[sprint]
+		var total, done float64
+		var remaining int
+		opts.maxPoints = 0
 		for _, ji := range list {
 			total += ji.Fields.Points
+			if ji.Fields.Status.Category.Key == "done" {
+				done += ji.Fields.Points
+			}
+			remaining += ji.Fields.Time.RemainingEstimateSeconds
+			opts.maxPoints = max(opts.maxPoints, ji.Fields.Points)
+		}
+		header := sprint
+		if r := ranges[sprint]; r != "" {
+			header += " (" + r + ")"
+		}
+		sum := formatPoints(total) + " pts"
+		if opts.PointsProgress {
+			sum += ", " + formatPoints(done) + " done"
+		}
+		if opts.Time {
+			sum = formatDuration(remaining) + " remaining"
+		}
+		rows = append(rows, row{header: fmt.Sprintf(
+			"Sprint: %s (%d issues, %s)",
+			header, len(list), sum,
+		)})
+		if opts.Compact {
+			continue
 		}
-		lines = append(lines, fmt.Sprintf(
-			"Sprint: %s (%d issues, %s pts)",
-			sprint, len(list), formatPoints(total),
-		))
 
+		if len(opts.LabelPriority) > 0 {
+			sort.SliceStable(list, func(i, j int) bool {
+				return labelRank(list[i], opts.LabelPriority) < labelRank(list[j], opts.LabelPriority)
+			})
+		}
 		for _, ji := range list {
-			f := ji.Fields
-			lines = append(lines, fmt.Sprintf(
-				"  %s\t%s\t%s\t%s\t%s",
-				ji.Key,
-				formatPoints(f.Points),
-				f.Status.Name,
-				f.IssueType.Name,
-				f.Summary,
-			))
+			c := rowCells(ji, opts)
+			rows = append(rows, row{cells: c, color: rowColor(ji, opts)})
+			cells = append(cells, c)
+		}
+		rows = append(rows, row{})
+	}
+
+	// Padded widths are shared by all sprints so every group lines up.
+	var widths []int
+	if opts.Padded {
+		widths = columnWidths(cells)
+	}
+	if opts.Wrap > 0 {
+		for i := range rows {
+			if rows[i].cells != nil {
+				rows[i].more = wrapSummary(rows[i].cells, widths, 2, opts)
+			}
+		}
+		// Wrapping only shortened summaries; the columns before them
+		// keep their widths.
+		if opts.Padded {
+			widths = columnWidths(cells)
 		}
-		lines = append(lines, "")
 	}
 
+	var lines []string
+	for _, r := range rows {
+		switch {
+		case r.cells == nil:
+			lines = append(lines, r.header)
+		case opts.Padded:
+			lines = append(lines, "  "+colorize(r.color, joinPadded(r.cells, widths)))
+		default:
+			lines = append(lines, "  "+colorize(r.color, strings.Join(r.cells, "\t")))
+		}
+		for _, m := range r.more {
+			lines = append(lines, colorize(r.color, m))
+		}
+	}
 	return strings.Join(lines, "\n")
 }
 
@@ -251,37 +1451,48 @@ func issueLabel(i JiraIssue) string {
 	return i.Key + "  " + i.Fields.Summary + "  [" + i.Fields.Status.Name + "]"
 }
 
-func pickFromList(label string, items []string) int {
-	r := bufio.NewReader(os.Stdin)
+func pickFromList(label string, items []string) (int, error) {
+	if !promptIsTerminal() {
+		return -1, errNotTerminal
+	}
 	for i, item := range items {
 		fmt.Printf("%d) %s\n", i+1, item)
 	}
 	fmt.Printf("%s (1-%d, empty to cancel): ", label, len(items))
 
-	line, err := r.ReadString('\n')
-	if err != nil {
-		log.Fatalf("read error: %v", err)
+	trim, ok, err := readAnswer()
+	if !ok {
+		return -1, nil
+	}
+	// EOF (Ctrl-D) ends the answer like a newline would.
+	if err != nil && !errors.Is(err, io.EOF) {
+		return -1, fmt.Errorf("reading selection: %w", err)
 	}
 
-	trim := strings.TrimSpace(line)
 	if trim == "" {
-		return -1
+		return -1, nil
 	}
 
 	n, err := strconv.Atoi(trim)
 	if err != nil || n < 1 || n > len(items) {
-		log.Fatalf("invalid selection")
+		return -1, usageError("invalid selection %q (want 1-%d)", trim, len(items))
 	}
-	return n - 1
+	return n - 1, nil
 }
 
+// selectKey is -key: the issue selectIssue returns without prompting.
+var selectKey string
+
 func selectIssue(cfg JiraConfig, filter func(JiraIssue) bool, prompt string) (*JiraIssue, error) {
 	issues, err := getIssues(cfg)
 	if err != nil {
 		return nil, err
 	}
+	if selectKey != "" {
+		return selectIssueByKey(cfg, issues, expandKey(cfg, selectKey), filter, prompt)
+	}
 
-	fmt.Println(formatIssuesBySprint(issues))
+	fmt.Println(formatIssuesBySprint(issues, formatOptions{}))
 
 	var list []JiraIssue
 	for _, ji := range issues {
@@ -291,6 +1502,9 @@ func selectIssue(cfg JiraConfig, filter func(JiraIssue) bool, prompt string) (*J
 	}
 
 	if len(list) == 0 {
+		if len(issues) > 0 {
+			fmt.Println("No matching issues found")
+		}
 		return nil, nil
 	}
 
@@ -299,7 +1513,10 @@ func selectIssue(cfg JiraConfig, filter func(JiraIssue) bool, prompt string) (*J
 		labels[i] = issueLabel(is)
 	}
 
-	idx := pickFromList(prompt, labels)
+	idx, err := pickFromList(prompt, labels)
+	if err != nil {
+		return nil, err
+	}
 	if idx == -1 {
 		return nil, nil
 	}
@@ -307,7 +1524,35 @@ func selectIssue(cfg JiraConfig, filter func(JiraIssue) bool, prompt string) (*J
 	return &list[idx], nil
 }
 
-func interactiveFlow(cfg JiraConfig) error {
+// skipPreflight disables the credential check before interactive flows
+// and the permission check before sprint changes.
+var skipPreflight bool
+
+func getMyself(cfg JiraConfig) (User, error) {
+	var u User
+	err := doJSON(cfg, http.MethodGet, apiURL(cfg, nil, "rest/api/3/myself"), nil, &u)
+	return u, err
+}
+
+// preflight checks the credentials up front so an interactive flow
+// doesn't fail only after the user has picked an issue.
+func preflight(cfg JiraConfig) error {
+	if skipPreflight {
+		return nil
+	}
+	_, err := getMyself(cfg)
+	return err
+}
+
+func interactiveFlow(cfg JiraConfig, sprintMove bool) error {
+	// Refuse before fetching anything; the status is always asked for.
+	if !promptIsTerminal() {
+		return errNotTerminal
+	}
+	if err := preflight(cfg); err != nil {
+		return err
+	}
+
 	issue, err := selectIssue(cfg, nil, "Select issue")
 	if err != nil {
 		return err
@@ -317,32 +1562,76 @@ func interactiveFlow(cfg JiraConfig) error {
 	}
 
 	statuses := []string{"Open", "In Progress", "In Review", "In Testing", "Resolved"}
-	si := pickFromList("Select new status", statuses)
+	si, err := pickFromList("Select new status", statuses)
+	if err != nil {
+		return err
+	}
 	if si == -1 {
 		return nil
 	}
 
-	if err := transitionIssue(cfg, issue.Key, statuses[si]); err != nil {
+	t, err := transitionIssue(cfg, issue.Key, statuses[si], transitionOptions{})
+	if err != nil {
 		return err
 	}
 
 	fmt.Printf("Transitioned %s to %q\n", issue.Key, statuses[si])
 
-	if len(issue.Fields.Sprints) == 0 {
+	backlogged, err := backlogIfDone(cfg, issue.Key, t)
+	if err != nil {
+		return err
+	}
+	if sprintMove && !backlogged && len(issue.Fields.Sprints) == 0 {
 		if err := moveIssueToCurrentSprint(cfg, issue.Key); err != nil {
 			return err
 		}
 		fmt.Printf("Added %s to active sprint\n", issue.Key)
 	}
+	if openAfter {
+		openIssue(cfg, issue.Key)
+	}
 
 	return nil
 }
 
+// selectIssueByKey is selectIssue for scripts: key is taken from the
+// listing, or fetched when it isn't one of the user's issues, and must
+// pass the same filter the picker would have applied.
+func selectIssueByKey(cfg JiraConfig, issues []JiraIssue, key string, filter func(JiraIssue) bool, prompt string) (*JiraIssue, error) {
+	var found *JiraIssue
+	for i := range issues {
+		if strings.EqualFold(issues[i].Key, key) {
+			found = &issues[i]
+			break
+		}
+	}
+	if found == nil {
+		ji, err := getIssue(cfg, key, searchFields...)
+		if err != nil {
+			return nil, err
+		}
+		found = &ji
+	}
+	if filter != nil && !filter(*found) {
+		return nil, usageError("%s would not be offered here (%s)", found.Key, prompt)
+	}
+	return found, nil
+}
+
+func isUnsprinted(j JiraIssue) bool {
+	return len(j.Fields.Sprints) == 0
+}
+
 func moveFlow(cfg JiraConfig, issueKey string) error {
+	if issueKey == "" && selectKey == "" && !promptIsTerminal() {
+		return errNotTerminal
+	}
+	if err := preflight(cfg); err != nil {
+		return err
+	}
+
 	if issueKey == "" {
-		issue, err := selectIssue(cfg, func(j JiraIssue) bool {
-			return len(j.Fields.Sprints) == 0
-		}, "Select issue to move")
+		issue, err := selectIssue(cfg, isUnsprinted, "Select issue to move")
 		if err != nil {
 			return err
 		}
@@ -352,56 +1641,366 @@ func moveFlow(cfg JiraConfig, issueKey string) error {
 		issueKey = issue.Key
 	}
 
+	if err := checkPermissions(cfg, issueKey, sprintPermissions...); err != nil {
+		return err
+	}
 	if err := moveIssueToCurrentSprint(cfg, issueKey); err != nil {
 		return err
 	}
 	fmt.Printf("Added %s to active sprint\n", strings.ToUpper(issueKey))
+	if openAfter {
+		openIssue(cfg, strings.ToUpper(issueKey))
+	}
+	return nil
+}
+
+// moveToSprintID adds issueKey to sprint id as given, without looking up
+// the active sprint or resolving names.
+func moveToSprintID(cfg JiraConfig, issueKey string, id int) error {
+	if err := checkPermissions(cfg, issueKey, sprintPermissions...); err != nil {
+		return err
+	}
+	if err := addIssueToSprint(cfg, id, issueKey); err != nil {
+		return err
+	}
+	fmt.Printf("Added %s to sprint %d\n", issueKey, id)
+	if openAfter {
+		openIssue(cfg, issueKey)
+	}
+	return nil
+}
+
+// confirmThreshold is the batch size above which bulk operations ask
+// before going ahead.
+const confirmThreshold = 5
+
+func confirm(prompt string) bool {
+	if !promptIsTerminal() {
+		fail(usageError("%s needs confirming on a terminal; pass -yes to skip the prompt", strings.TrimSuffix(prompt, "?")))
+	}
+	fmt.Printf("%s [y/N]: ", prompt)
+	line, _, _ := readAnswer()
+	switch strings.ToLower(line) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// moveAllFlow moves every unsprinted issue into the active sprint,
+// -batch-size issues per request.
+func moveAllFlow(cfg JiraConfig, yes bool) error {
+	issues, err := getIssues(cfg)
+	if err != nil {
+		return err
+	}
+
+	var keys []string
+	for _, ji := range issues {
+		if isUnsprinted(ji) {
+			keys = append(keys, ji.Key)
+		}
+	}
+	if len(keys) == 0 {
+		fmt.Println("No issues to move")
+		return nil
+	}
+
+	s, err := findActiveSprint(issues)
+	if err != nil {
+		return err
+	}
+
+	if len(keys) > confirmThreshold && !yes {
+		if !confirm(fmt.Sprintf("Move %d issues to %s?", len(keys), s.Name)) {
+			return nil
+		}
+	}
+
+	if err := checkPermissions(cfg, keys[0], sprintPermissions...); err != nil {
+		return err
+	}
+	if err := addIssuesToSprint(cfg, s.ID, keys); err != nil {
+		return err
+	}
+	fmt.Printf("Added %d issues to %s\n", len(keys), s.Name)
+	return nil
+}
+
+func moveCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("move")
+	all := fs.Bool("all", false, "move every issue that is not in a sprint")
+	yes := fs.Bool("yes", false, "don't ask for confirmation")
+	fs.IntVar(&batchSize, "batch-size", batchSize, "with -all, add at most this many issues per request")
+	fs.BoolVar(&failFast, "fail-fast", failFast, "with -all, stop at the first failing batch")
+	fs.BoolVar(&skipPreflight, "skip-preflight", skipPreflight, "don't check credentials and permissions first")
+	fs.BoolVar(&openAfter, "open-after", openAfter, "open the issue in a browser afterwards")
+	fromBranch := fs.Bool("branch", false, "take the issue key from the current git branch")
+	sprintID := fs.String("sprint-id", "", "add the issue to this sprint ID instead of the active sprint")
+	fs.StringVar(&selectKey, "key", selectKey, "move this issue without prompting, if it is not in a sprint yet")
+	fs.BoolVar(&noSprintFallback, "no-active-sprint-fallback", noSprintFallback, "fail unless -sprint-id is given, instead of inferring the active sprint from your issues")
+	pos := parseArgs(fs, args)
+	if len(pos) > 1 {
+		return usageError("usage: jira-cli move [KEY]")
+	}
+	if selectKey != "" && (len(pos) > 0 || *fromBranch || *all || *sprintID != "") {
+		return usageError("-key cannot be combined with a key argument, -branch, -all or -sprint-id")
+	}
+	if *fromBranch {
+		if len(pos) > 0 {
+			return usageError("-branch does not take an issue key")
+		}
+		k, err := branchKey(cfg)
+		if err != nil {
+			return err
+		}
+		pos = []string{k}
+	}
+
+	if *sprintID != "" {
+		id, err := strconv.Atoi(*sprintID)
+		if err != nil || id <= 0 {
+			return usageError("-sprint-id must be a positive integer, got %q", *sprintID)
+		}
+		if *all || len(pos) != 1 {
+			return usageError("-sprint-id needs exactly one issue key")
+		}
+		return moveToSprintID(cfg, expandKey(cfg, pos[0]), id)
+	}
+	if noSprintFallback {
+		return errNoSprintFallback
+	}
+
+	if *all {
+		if len(pos) > 0 {
+			return usageError("-all does not take an issue key")
+		}
+		return moveAllFlow(cfg, *yes)
+	}
+
+	var key string
+	if len(pos) > 0 {
+		key = expandKey(cfg, pos[0])
+	}
+	return moveFlow(cfg, key)
+}
+
+type command struct {
+	Name    string
+	Summary string
+	Run     func(cfg JiraConfig, args []string) error
+}
+
+// commands is filled in by init: each command's help looks up its own
+// summary here, which a plain initializer would make a cycle.
+var commands []command
+
+func init() {
+	commands = []command{
+		{"aliases", "list the status aliases from the config file", aliasesCmd},
+		{"api", "send a raw request to the Jira API", apiCmd},
+		{"attach", "upload files to an issue", attachCmd},
+		{"backlog", "move issues out of their sprint", backlogCmd},
+		{"clone", "copy an issue into a new one", cloneCmd},
+		{"component", "set an issue's components", componentCmd},
+		{"create", "create an issue", createCmd},
+		{"debug-info", "print setup details for bug reports, secrets redacted", debugInfoCmd},
+		{"detail", "show one issue and its subtasks", detailCmd},
+		{"edit", "edit an issue's summary in $EDITOR", editCmd},
+		{"fields", "list an issue's raw fields with their IDs and names", fieldsCmd},
+		{"history", "show an issue's status changes", historyCmd},
+		{"metrics", "print issue counts for configured queries as Prometheus metrics", metricsCmd},
+		{"move", "move issues into the active sprint", moveCmd},
+		{"open", "open an issue in the browser", openCmd},
+		{"ping", "check connectivity and report latency", pingCmd},
+		{"sprint", "start or close a sprint", sprintCmd},
+		{"subtask", "create a subtask under an issue", subtaskCmd},
+		{"summary", "print a one-line report of your issues", summaryCmd},
+		{"transition", "move issues to another status", transitionCmd},
+	}
+}
+
+func lookupCommand(name string) *command {
+	for i := range commands {
+		if commands[i].Name == name {
+			return &commands[i]
+		}
+	}
 	return nil
 }
 
+// parseArgs parses fs from args, allowing flags to appear between
+// positional arguments, and returns the positional arguments.
+func parseArgs(fs *flag.FlagSet, args []string) []string {
+	var pos []string
+	for {
+		fs.Parse(args)
+		args = fs.Args()
+		if len(args) == 0 {
+			return pos
+		}
+		pos = append(pos, args[0])
+		args = args[1:]
+	}
+}
+
 func main() {
-	cfg := JiraConfig{
-		Email: mustEnv("JIRA_EMAIL"),
-		URL:   strings.TrimRight(mustEnv("JIRA_URL"), "/"),
-		Token: mustEnv("JIRA_API_TOKEN"),
+	fs := flag.NewFlagSet("jira-cli", flag.ExitOnError)
+	interactive := fs.Bool("i", false, "interactive mode")
+	move := fs.Bool("m", false, "move an issue into the active sprint")
+	noSprintMove := fs.Bool("no-sprint-move", false, "don't add unsprinted issues to the active sprint in interactive mode")
+	var lo listOptions
+	lo.register(fs)
+	pageSize := fs.Int("page-size", 0, "issues per search request, at most 100 (default $JIRA_PAGE_SIZE or 100)")
+	locale := fs.String("locale", envLocale(), "locale for number formatting, e.g. en or de")
+	fs.StringVar(&tzOverride, "tz", "", "time zone for displayed times, e.g. Europe/Berlin (default: your Jira profile's)")
+	fs.BoolVar(&verbose, "v", false, "verbose output on stderr")
+	fs.BoolVar(&skipPreflight, "skip-preflight", false, "don't check credentials and permissions before interactive flows and sprint changes")
+	fs.StringVar(&selectKey, "key", "", "with -i or -m, use this issue instead of prompting for one")
+	fs.DurationVar(&promptTimeout, "prompt-timeout", 0, "cancel prompts left unanswered this long, e.g. 2m (default: wait)")
+	fs.BoolVar(&noSprintFallback, "no-active-sprint-fallback", false, "fail sprint moves that don't name a sprint instead of inferring the active one")
+	fs.BoolVar(&failFast, "fail-fast", false, "stop batch operations at the first failing issue")
+	fs.StringVar(&resultsPath, "results", "", "record each key's outcome of a batch in this JSON file")
+	fs.StringVar(&resumePath, "resume", "", "skip keys that succeeded in this results file, and update it")
+	fs.BoolVar(&openAfter, "open-after", false, "open the issue in a browser after -m, -i or a status change")
+	fs.BoolVar(&backlogOnDone, "backlog-on-done", false, "move issues to the backlog after -i changes their status to a Done-category one")
+	fs.DurationVar(&cacheTTL, "cache-ttl", 0, "serve reads from a local cache for this long, e.g. 5m (default $JIRA_CACHE_TTL, or off)")
+	fs.BoolVar(&noCache, "no-cache", false, "don't read from the response cache")
+	fs.BoolVar(&retrySummary, "retry-summary", false, "print how many requests were sent and retried when done")
+	fs.BoolVar(&noFollowRedirects, "no-follow-redirects", false, "report redirects instead of following them")
+	fs.DurationVar(&httpClient.Timeout, "timeout", httpClient.Timeout, "HTTP request timeout")
+	fs.StringVar(&errorFormat, "error-format", "text", "error output format: text or json")
+	noColor := fs.Bool("no-color", false, "disable color and the progress spinner")
+	fs.StringVar(&themeName, "theme", "", "color theme: default, dark, light, mono, or a [theme.NAME] config section (default $JIRA_THEME)")
+	fs.Usage = func() { writeUsage(fs.Output(), fs) }
+	fs.Parse(os.Args[1:])
+	handleInterrupts()
+	defer printRetrySummary()
+
+	if *noColor {
+		os.Setenv("NO_COLOR", "1")
+	}
+	// debugf lines would tear through the spinner.
+	if colorEnabled(os.Stderr) && !verbose {
+		progressOut = os.Stderr
 	}
 
-	args := os.Args[1:]
+	if errorFormat != "text" && errorFormat != "json" {
+		fail(usageError("invalid -error-format %q (want text or json)", errorFormat))
+	}
+	setLocale(*locale)
 
-	if len(args) == 0 {
-		issues, err := getIssues(cfg)
+	// Help needs no Jira configuration, so answer it before loading one.
+	if args := fs.Args(); len(args) > 0 {
+		if args[0] == "help" {
+			if err := helpCmd(fs, args[1:]); err != nil {
+				fail(err)
+			}
+			return
+		}
+		if cmd := lookupCommand(args[0]); cmd != nil && wantsHelp(args[1:]) {
+			commandUsage(cmd)
+		}
+	}
+
+	file, err := loadConfig(configPath())
+	if err != nil {
+		fail(err)
+	}
+	if activeTheme, err = loadTheme(file, cmp.Or(themeName, os.Getenv("JIRA_THEME"))); err != nil {
+		fail(err)
+	}
+	if v := file.Get("", "backlog_on_done"); v != "" && !backlogOnDone {
+		on, err := strconv.ParseBool(v)
 		if err != nil {
-			log.Fatal(err)
+			fail(usageError("invalid backlog_on_done %q in %s (want true or false)", v, configPath()))
 		}
-		fmt.Println(formatIssuesBySprint(issues))
-		return
+		backlogOnDone = on
 	}
 
-	if args[0] == "-i" {
-		if err := interactiveFlow(cfg); err != nil {
-			log.Fatal(err)
+	cfg := JiraConfig{
+		Email: mustSetting(file, "JIRA_EMAIL", "email"),
+		URL:   strings.TrimRight(mustSetting(file, "JIRA_URL", "url"), "/"),
+		Token: mustSetting(file, "JIRA_API_TOKEN", "token"),
+
+		DefaultProject: strings.ToUpper(strings.TrimSpace(os.Getenv("JIRA_DEFAULT_PROJECT"))),
+		SearchPath:     searchJQLPath,
+		File:           file,
+		EpicLinkField:  cmp.Or(strings.TrimSpace(os.Getenv("JIRA_EPIC_LINK_FIELD")), defaultEpicLinkField),
+	}
+	if u, err := url.Parse(cfg.URL); err != nil || u.Scheme == "" || u.Host == "" {
+		fail(usageError("invalid JIRA_URL %q (want e.g. https://example.atlassian.net)", cfg.URL))
+	}
+	if v := os.Getenv("JIRA_PAGE_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			fail(usageError("invalid JIRA_PAGE_SIZE %q", v))
 		}
-		return
+		cfg.PageSize = n
+	}
+	if *pageSize != 0 {
+		cfg.PageSize = *pageSize
+	}
+	if v, ok := os.LookupEnv("JIRA_ASSIGNEE_CLAUSE"); ok {
+		if err := checkClause(v); err != nil {
+			fail(usageError("invalid JIRA_ASSIGNEE_CLAUSE: %v", err))
+		}
+		cfg.AssigneeClause = strings.TrimSpace(v)
+	}
+	if v := os.Getenv("JIRA_RATE_LIMIT"); v != "" {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil || rate <= 0 {
+			fail(usageError("invalid JIRA_RATE_LIMIT %q (want requests per second, e.g. 5 or 0.5)", v))
+		}
+		limiter = newRateLimiter(rate)
+	}
+	if v := os.Getenv("JIRA_CACHE_TTL"); v != "" && cacheTTL == 0 {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl < 0 {
+			fail(usageError("invalid JIRA_CACHE_TTL %q (want a duration, e.g. 5m)", v))
+		}
+		cacheTTL = ttl
+	}
+	if cacheTTL < 0 {
+		fail(usageError("-cache-ttl cannot be negative"))
 	}
+	if os.Getenv("JIRA_SEARCH_API") == "legacy" {
+		cfg.SearchPath = searchLegacyPath
+	}
+
+	args := fs.Args()
 
-	if args[0] == "-m" {
-		var key string
-		if len(args) > 1 {
-			key = args[1]
+	if len(args) > 0 {
+		if cmd := lookupCommand(args[0]); cmd != nil {
+			if err := cmd.Run(cfg, args[1:]); err != nil {
+				fail(err)
+			}
+			return
 		}
-		if err := moveFlow(cfg, key); err != nil {
-			log.Fatal(err)
+	}
+
+	if *interactive {
+		if err := interactiveFlow(cfg, !*noSprintMove); err != nil {
+			fail(err)
 		}
 		return
 	}
 
-	issueKey := args[0]
-	status := strings.TrimSpace(strings.Join(args[1:], " "))
-	if status == "" {
-		log.Fatalf("missing target status")
+	if *move {
+		if err := moveCmd(cfg, args); err != nil {
+			fail(err)
+		}
+		return
+	}
+
+	if len(args) == 0 {
+		if err := listFlow(cfg, lo); err != nil {
+			fail(err)
+		}
+		return
 	}
-	if err := transitionIssue(cfg, issueKey, status); err != nil {
-		log.Fatal(err)
+
+	if err := transitionCmd(cfg, args); err != nil {
+		fail(err)
 	}
-	fmt.Printf("Transitioned %s to %q\n", issueKey, status)
 }
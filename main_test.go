@@ -0,0 +1,1077 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+// fakeJira is an httptest server answering the routes a test registers,
+// with Go 1.22 mux patterns such as "POST /rest/api/3/search/jql", and
+// recording every request it gets. Anything else is a 404.
+type fakeJira struct {
+	*httptest.Server
+	mux *http.ServeMux
+
+	mu   sync.Mutex
+	reqs []fakeRequest
+}
+
+type fakeRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   string
+}
+
+func newFakeJira(t *testing.T) *fakeJira {
+	t.Helper()
+	f := &fakeJira{mux: http.NewServeMux()}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		f.mu.Lock()
+		f.reqs = append(f.reqs, fakeRequest{r.Method, r.URL.Path, r.URL.Query(), r.Header.Clone(), string(body)})
+		f.mu.Unlock()
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+		f.mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// reply answers pattern with status and body encoded as JSON; a nil
+// body sends none.
+func (f *fakeJira) reply(pattern string, status int, body any) {
+	f.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if body == nil {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	})
+}
+
+func (f *fakeJira) config() JiraConfig {
+	return JiraConfig{Email: "me@example.com", Token: "secret", URL: f.URL, SearchPath: searchJQLPath}
+}
+
+// requests returns what was sent with method to path.
+func (f *fakeJira) requests(method, path string) []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []fakeRequest
+	for _, r := range f.reqs {
+		if r.Method == method && r.Path == path {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// setGlobal sets *p to v for the rest of the test.
+func setGlobal[T any](t *testing.T, p *T, v T) {
+	t.Helper()
+	old := *p
+	*p = v
+	t.Cleanup(func() { *p = old })
+}
+
+// captureStdout returns what fn printed to os.Stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	return captureFile(t, &os.Stdout, fn)
+}
+
+// captureStderr returns what fn printed to os.Stderr.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	return captureFile(t, &os.Stderr, fn)
+}
+
+func captureFile(t *testing.T, f **os.File, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := *f
+	*f = w
+	done := make(chan string)
+	go func() {
+		buf, _ := io.ReadAll(r)
+		done <- string(buf)
+	}()
+	defer func() { *f = old }()
+	fn()
+	w.Close()
+	return <-done
+}
+
+// answerPrompts feeds lines to the prompts as if typed on a terminal.
+func answerPrompts(t *testing.T, lines ...string) {
+	t.Helper()
+	setGlobal(t, &promptIsTerminal, func() bool { return true })
+	setGlobal[io.Reader](t, &stdin, strings.NewReader(strings.Join(lines, "\n")+"\n"))
+	setGlobal(t, &promptAnswers, nil)
+}
+
+func searchResult(issues ...JiraIssue) map[string]any {
+	return map[string]any{"issues": issues, "isLast": true}
+}
+
+func TestInteractiveFlowSprintMove(t *testing.T) {
+	for _, sprintMove := range []bool{true, false} {
+		f := newFakeJira(t)
+		unsprinted := JiraIssue{Key: "IS-1"}
+		unsprinted.Fields.Status.Name = "Open"
+		sprinted := JiraIssue{Key: "IS-2"}
+		sprinted.Fields.Sprints = []Sprint{{ID: 7, Name: "Sprint 7", State: "active"}}
+		f.reply("POST /rest/api/3/search/jql", 200, searchResult(unsprinted, sprinted))
+		f.reply("GET /rest/api/3/issue/IS-1/transitions", 200, map[string]any{
+			"transitions": []map[string]any{{"id": "21", "to": map[string]any{"name": "In Progress"}}},
+		})
+		f.reply("POST /rest/api/3/issue/IS-1/transitions", 204, nil)
+		f.reply("POST /rest/agile/1.0/sprint/7/issue", 204, nil)
+		setGlobal(t, &skipPreflight, true)
+		answerPrompts(t, "1", "2")
+
+		var err error
+		captureStdout(t, func() { err = interactiveFlow(f.config(), sprintMove) })
+		if err != nil {
+			t.Fatalf("sprintMove=%v: %v", sprintMove, err)
+		}
+		if n := len(f.requests("POST", "/rest/api/3/issue/IS-1/transitions")); n != 1 {
+			t.Errorf("sprintMove=%v: %d transition requests, want 1", sprintMove, n)
+		}
+		want := 0
+		if sprintMove {
+			want = 1
+		}
+		if n := len(f.requests("POST", "/rest/agile/1.0/sprint/7/issue")); n != want {
+			t.Errorf("sprintMove=%v: %d sprint-add requests, want %d", sprintMove, n, want)
+		}
+	}
+}
+
+func TestLinksWrapKey(t *testing.T) {
+	ji := JiraIssue{Key: "IS-1"}
+	ji.Fields.Summary = "Fix login"
+	out := formatIssuesBySprint([]JiraIssue{ji}, formatOptions{LinkBase: "https://example.atlassian.net"})
+	want := "\x1b]8;;https://example.atlassian.net/browse/IS-1\x1b\\IS-1\x1b]8;;\x1b\\"
+	if !strings.Contains(out, want) {
+		t.Errorf("listing does not link the key:\n%q", out)
+	}
+	if plain := formatIssuesBySprint([]JiraIssue{ji}, formatOptions{}); strings.Contains(plain, "\x1b]8") {
+		t.Errorf("listing without LinkBase has an OSC 8 escape:\n%q", plain)
+	}
+}
+
+func TestExpandKey(t *testing.T) {
+	cfg := JiraConfig{DefaultProject: "PROJ"}
+	tests := []struct{ in, want string }{
+		{"1234", "PROJ-1234"},
+		{"7", "PROJ-7"},
+		{"ABC-12", "ABC-12"},
+		{"PROJ-1", "PROJ-1"},
+		{"-", "-"},
+		{"12a", "12a"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := expandKey(cfg, tt.in); got != tt.want {
+			t.Errorf("expandKey(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+	if got := expandKey(JiraConfig{}, "1234"); got != "1234" {
+		t.Errorf("expandKey without a default project = %q, want 1234", got)
+	}
+}
+
+func TestSprintHeaderDates(t *testing.T) {
+	dated := sampleIssue("IS-1", "Open", 3)
+	dated.Fields.Sprints = []Sprint{{ID: 7, Name: "Sprint 12", State: "active",
+		StartDate: "2024-01-05T09:00:00.000+0000", EndDate: "2024-01-19T17:00:00.000+0000"}}
+	undated := sampleIssue("IS-2", "Open", 2)
+	undated.Fields.Sprints = []Sprint{{ID: 8, Name: "Sprint 13", State: "future"}}
+
+	out := formatIssuesBySprint([]JiraIssue{dated, undated}, formatOptions{Compact: true})
+	want := "Sprint: Sprint 12 (Jan 5–Jan 19) (1 issues, 3 pts)\nSprint: Sprint 13 (1 issues, 2 pts)"
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestMoveAllSendsOnlyUnsprintedIssues(t *testing.T) {
+	f := newFakeJira(t)
+	inSprint := sampleIssue("IS-2", "Open", 1)
+	inSprint.Fields.Sprints = []Sprint{{ID: 7, Name: "Sprint 7", State: "active"}}
+	f.reply("POST /rest/api/3/search/jql", 200, searchResult(
+		sampleIssue("IS-1", "Open", 1), inSprint, sampleIssue("IS-3", "In Progress", 2)))
+	f.reply("POST /rest/agile/1.0/sprint/7/issue", 204, nil)
+	setGlobal(t, &skipPreflight, true)
+
+	var err error
+	captureStdout(t, func() { err = moveAllFlow(f.config(), true) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs := f.requests("POST", "/rest/agile/1.0/sprint/7/issue")
+	if len(reqs) != 1 {
+		t.Fatalf("%d sprint-add requests, want 1", len(reqs))
+	}
+	if want := `{"issues":["IS-1","IS-3"]}`; reqs[0].Body != want {
+		t.Errorf("body = %s, want %s", reqs[0].Body, want)
+	}
+}
+
+func TestColumnsReorder(t *testing.T) {
+	names, err := parseColumns("Summary, key,status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ji := sampleIssue("IS-1", "Open", 3)
+	got := strings.Join(rowCells(ji, formatOptions{Columns: names}), "|")
+	if want := "Summary of IS-1|IS-1|Open"; got != want {
+		t.Errorf("cells = %q, want %q", got, want)
+	}
+}
+
+func TestColumnsUnknown(t *testing.T) {
+	_, err := parseColumns("key,bogus")
+	if !errors.Is(err, ErrUsage) || !strings.Contains(err.Error(), `unknown column "bogus"`) {
+		t.Errorf("err = %v, want an unknown column usage error", err)
+	}
+	if _, err := parseColumns(" , "); !errors.Is(err, ErrUsage) {
+		t.Errorf("empty spec: err = %v, want a usage error", err)
+	}
+}
+
+// replyTransitions offers every issue transitions to the given statuses,
+// with ids 1, 2, ... and each status's category key after a colon, as
+// in "Done:done".
+func (f *fakeJira) replyTransitions(statuses ...string) {
+	var ts []map[string]any
+	for i, s := range statuses {
+		name, cat, _ := strings.Cut(s, ":")
+		ts = append(ts, map[string]any{
+			"id": strconv.Itoa(i + 1),
+			"to": map[string]any{"name": name, "statusCategory": map[string]any{"key": cat}},
+		})
+	}
+	f.reply("GET /rest/api/3/issue/{key}/transitions", 200, map[string]any{"transitions": ts})
+	f.reply("POST /rest/api/3/issue/{key}/transitions", 204, nil)
+}
+
+// transitioned lists the issues a transition was posted for, in order.
+func (f *fakeJira) transitioned() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var keys []string
+	for _, r := range f.reqs {
+		if r.Method == "POST" && strings.HasSuffix(r.Path, "/transitions") {
+			keys = append(keys, strings.Split(r.Path, "/")[5])
+		}
+	}
+	return keys
+}
+
+func TestTransitionKeysFromStdin(t *testing.T) {
+	f := newFakeJira(t)
+	f.replyTransitions("In Progress", "Done")
+	setGlobal[io.Reader](t, &stdin, strings.NewReader("IS-1\n\n  IS-2 \n3\n"))
+	cfg := f.config()
+	cfg.DefaultProject = "IS"
+
+	var err error
+	out := captureStdout(t, func() { err = transitionCmd(cfg, []string{"-", "Done"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.transitioned(); !slices.Equal(got, []string{"IS-1", "IS-2", "IS-3"}) {
+		t.Errorf("transitioned %q", got)
+	}
+	if want := "Transitioned IS-3 to \"Done\"\n"; !strings.HasSuffix(out, want) {
+		t.Errorf("output:\n%s", out)
+	}
+}
+
+func doneTransition(t *testing.T) *Transition {
+	t.Helper()
+	var tr Transition
+	err := json.Unmarshal([]byte(`{"id": "31", "to": {"name": "Done"}, "fields": {"resolution": {
+		"required": true, "allowedValues": [{"name": "Fixed"}, {"name": "Won't Do"}]}}}`), &tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &tr
+}
+
+func TestTransitionBodyResolution(t *testing.T) {
+	body, err := transitionBody(doneTransition(t), transitionOptions{Resolution: "won't do"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := json.Marshal(body)
+	if want := `{"fields":{"resolution":{"name":"Won't Do"}},"transition":{"id":"31"}}`; string(got) != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	_, err = transitionBody(doneTransition(t), transitionOptions{})
+	if !errors.Is(err, ErrUsage) || !strings.Contains(err.Error(), "requires -resolution (allowed: Fixed, Won't Do)") {
+		t.Errorf("missing required resolution: err = %v", err)
+	}
+	if _, err := transitionBody(doneTransition(t), transitionOptions{Resolution: "Duplicate"}); !errors.Is(err, ErrUsage) {
+		t.Errorf("disallowed resolution: err = %v, want a usage error", err)
+	}
+	if _, err := transitionBody(&Transition{ID: "21"}, transitionOptions{Resolution: "Fixed"}); !errors.Is(err, ErrUsage) {
+		t.Errorf("resolution on a transition without one: err = %v, want a usage error", err)
+	}
+}
+
+func TestInteractiveFlowPreflightUnauthorized(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("GET /rest/api/3/myself", 401, map[string]any{"errorMessages": []string{"Unauthorized"}})
+	f.reply("POST /rest/api/3/search/jql", 200, searchResult(sampleIssue("IS-1", "Open", 1)))
+	answerPrompts(t, "1", "2")
+
+	var err error
+	out := captureStdout(t, func() { err = interactiveFlow(f.config(), false) })
+	if !errors.Is(err, ErrAuth) {
+		t.Fatalf("err = %v, want ErrAuth", err)
+	}
+	if n := len(f.requests("POST", "/rest/api/3/search/jql")); n != 0 {
+		t.Errorf("%d searches after a failed preflight, want 0", n)
+	}
+	if out != "" {
+		t.Errorf("prompted before failing: %q", out)
+	}
+}
+
+func TestMatchTransition(t *testing.T) {
+	transitions := []Transition{{ID: "11"}, {ID: "21"}, {ID: "31"}, {ID: "41"}}
+	for i, name := range []string{"Open", "In Progress", "In-Progress", "Done"} {
+		transitions[i].To.Name = name
+	}
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"In Progress", "21"},
+		{"in progress", "21"},
+		{"  In   Progress ", "21"},
+		{"in\tprogress", "21"},
+		{"in_progress", "21"},
+		{"IN_PROGRESS", "21"},
+		// An exact match beats a normalized one.
+		{"in-progress", "31"},
+		{"done", "41"},
+		{"InProgress", ""},
+		{"Closed", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if m := matchTransition(transitions, tt.target); m != nil {
+			got = m.ID
+		}
+		if got != tt.want {
+			t.Errorf("matchTransition(%q) = %q, want %q", tt.target, got, tt.want)
+		}
+	}
+
+	// Without a hyphenated status, the hyphenated form falls back to it.
+	if m := matchTransition(transitions[:2], "in-progress"); m == nil || m.ID != "21" {
+		t.Errorf("matchTransition(in-progress) = %+v, want In Progress", m)
+	}
+}
+
+func TestNullIssuesSayNoIssuesFound(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("POST /rest/api/3/search/jql", 200, map[string]any{"issues": nil, "isLast": true})
+
+	issues, err := searchIssues(f.config(), "project = X")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issues == nil || len(issues) != 0 {
+		t.Errorf("issues = %#v, want an empty slice", issues)
+	}
+
+	out := captureStdout(t, func() { err = listFlow(f.config(), listOptions{}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != noIssuesMessage+"\n" {
+		t.Errorf("list output = %q, want %q", out, noIssuesMessage+"\n")
+	}
+
+	answerPrompts(t, "1")
+	var picked *JiraIssue
+	out = captureStdout(t, func() { picked, err = selectIssue(f.config(), nil, "Select issue") })
+	if err != nil || picked != nil {
+		t.Fatalf("selectIssue = %v, %v; want nothing picked", picked, err)
+	}
+	if out != noIssuesMessage+"\n" {
+		t.Errorf("selection output = %q, want %q", out, noIssuesMessage+"\n")
+	}
+}
+
+func TestPointBarScaling(t *testing.T) {
+	tests := []struct {
+		p, max float64
+		want   string
+	}{
+		{8, 8, "████"},
+		{4, 8, "██░░"},
+		{3, 8, "██░░"},
+		{1, 8, "█░░░"},
+		{0.5, 8, "█░░░"},
+		{0, 8, "░░░░"},
+		{0, 0, "░░░░"},
+	}
+	for _, tt := range tests {
+		if got := pointBar(tt.p, tt.max); got != tt.want {
+			t.Errorf("pointBar(%v, %v) = %q, want %q", tt.p, tt.max, got, tt.want)
+		}
+	}
+
+	// Each sprint scales to its own largest issue.
+	a, b, c := sampleIssue("IS-1", "Open", 8), sampleIssue("IS-2", "Open", 2), sampleIssue("IS-3", "Open", 2)
+	a.Fields.Sprints = []Sprint{{ID: 1, Name: "S1", State: "active"}}
+	b.Fields.Sprints = a.Fields.Sprints
+	out := formatIssuesBySprint([]JiraIssue{a, b, c}, formatOptions{PointBars: true})
+	for _, want := range []string{"████ 8", "█░░░ 2", "████ 2"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("listing lacks %q:\n%s", want, out)
+		}
+	}
+	if plain := formatIssuesBySprint([]JiraIssue{a}, formatOptions{}); strings.Contains(plain, "█") {
+		t.Errorf("bars drawn without PointBars:\n%s", plain)
+	}
+}
+
+func TestEpicMarker(t *testing.T) {
+	epic := sampleIssue("IS-1", "Open", 0)
+	epic.Fields.IssueType.Name = "Epic"
+	out := formatIssuesBySprint([]JiraIssue{epic, sampleIssue("IS-2", "Open", 1)}, formatOptions{})
+	if !strings.Contains(out, epicMarker+"Summary of IS-1") {
+		t.Errorf("epic summary is not marked:\n%s", out)
+	}
+	if strings.Contains(out, epicMarker+"Summary of IS-2") {
+		t.Errorf("story summary is marked:\n%s", out)
+	}
+}
+
+func TestSearchFallsBackOnMissingEndpoint(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("POST /rest/api/3/search/jql", 404, map[string]any{"errorMessages": []string{"Not Found"}})
+	f.reply("POST /rest/api/3/search", 200, map[string]any{
+		"issues": []JiraIssue{sampleIssue("IS-1", "Open", 1), sampleIssue("IS-2", "Open", 2)},
+		"total":  2,
+	})
+
+	issues, err := searchIssues(f.config(), "project = X")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[1].Key != "IS-2" {
+		t.Errorf("issues = %+v", issues)
+	}
+	if n := len(f.requests("POST", searchJQLPath)); n != 1 {
+		t.Errorf("%d requests to the primary, want 1", n)
+	}
+	legacy := f.requests("POST", searchLegacyPath)
+	if len(legacy) != 1 || !strings.Contains(legacy[0].Body, `"startAt":0`) {
+		t.Errorf("fallback requests = %+v", legacy)
+	}
+
+	// And the other way round, for a tenant configured for the legacy endpoint.
+	g := newFakeJira(t)
+	g.reply("POST /rest/api/3/search", 410, nil)
+	g.reply("POST /rest/api/3/search/jql", 200, searchResult(sampleIssue("IS-3", "Open", 1)))
+	cfg := g.config()
+	cfg.SearchPath = searchLegacyPath
+	if issues, err := searchIssues(cfg, "project = X"); err != nil || len(issues) != 1 {
+		t.Errorf("legacy primary = %v, %v", issues, err)
+	}
+}
+
+func TestPaddedAlignment(t *testing.T) {
+	a := sampleIssue("IS-1", "Open", 1)
+	a.Fields.Summary = "Short"
+	b := sampleIssue("LONGPROJ-1234", "In Progress", 13)
+	b.Fields.Summary = "A much longer summary"
+
+	out := formatIssuesBySprint([]JiraIssue{a, b}, formatOptions{Padded: true, Columns: []string{"key", "points", "status", "summary"}})
+	want := "Sprint: Backlog (2 issues, 14 pts)\n" +
+		"  IS-1           1   Open         Short\n" +
+		"  LONGPROJ-1234  13  In Progress  A much longer summary\n"
+	if out != want {
+		t.Errorf("got:\n%q\nwant:\n%q", out, want)
+	}
+	if strings.Contains(out, "\t") {
+		t.Error("padded output has tabs")
+	}
+}
+
+func TestTransitionWithComment(t *testing.T) {
+	f := newFakeJira(t)
+	f.replyTransitions("In Progress:indeterminate", "Blocked:indeterminate")
+
+	var err error
+	captureStdout(t, func() {
+		err = transitionCmd(f.config(), []string{"-comment", "Waiting on the API team", "IS-1", "Blocked"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	posts := f.requests("POST", "/rest/api/3/issue/IS-1/transitions")
+	if len(posts) != 1 {
+		t.Fatalf("%d transition requests, want 1", len(posts))
+	}
+	want := `{"transition":{"id":"2"},"update":{"comment":[{"add":{"body":{"type":"doc","version":1,` +
+		`"content":[{"type":"paragraph","content":[{"type":"text","text":"Waiting on the API team"}]}]}}}]}}`
+	if posts[0].Body != want {
+		t.Errorf("got  %s\nwant %s", posts[0].Body, want)
+	}
+
+	if err := transitionCmd(f.config(), []string{"-comment", " ", "IS-1", "Blocked"}); !errors.Is(err, ErrUsage) {
+		t.Errorf("empty -comment: err = %v, want a usage error", err)
+	}
+}
+
+func TestPageSizeSentAsMaxResults(t *testing.T) {
+	for _, tt := range []struct{ size, want int }{{25, 25}, {0, 100}, {500, 100}} {
+		f := newFakeJira(t)
+		f.reply("POST /rest/api/3/search/jql", 200, searchResult(sampleIssue("IS-1", "Open", 1)))
+		cfg := f.config()
+		cfg.PageSize = tt.size
+		if _, err := searchIssues(cfg, "project = X"); err != nil {
+			t.Fatal(err)
+		}
+		reqs := f.requests("POST", searchJQLPath)
+		if len(reqs) != 1 {
+			t.Fatalf("%d search requests, want 1", len(reqs))
+		}
+		var body struct{ MaxResults int }
+		json.Unmarshal([]byte(reqs[0].Body), &body)
+		if body.MaxResults != tt.want {
+			t.Errorf("page size %d: maxResults = %d, want %d", tt.size, body.MaxResults, tt.want)
+		}
+	}
+}
+
+func TestFlatListing(t *testing.T) {
+	a, b, c := sampleIssue("IS-3", "Open", 1), sampleIssue("IS-1", "Open", 2), sampleIssue("IS-2", "Done", 3)
+	a.Fields.Sprints = []Sprint{{ID: 1, Name: "S1", State: "active"}}
+	b.Fields.Sprints = []Sprint{{ID: 2, Name: "S2", State: "future"}}
+	f := newFakeJira(t)
+	f.reply("POST /rest/api/3/search/jql", 200, searchResult(a, b, c))
+
+	var err error
+	out := captureStdout(t, func() { err = listFlow(f.config(), listOptions{Flat: true, Sort: "key"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "Sprint:") {
+		t.Errorf("flat output has sprint headers:\n%s", out)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	var keys []string
+	for _, l := range lines[1:] {
+		k, _, _ := strings.Cut(l, "\t")
+		keys = append(keys, k)
+	}
+	if !strings.HasPrefix(lines[0], "KEY\t") || !slices.Equal(keys, []string{"IS-1", "IS-2", "IS-3"}) {
+		t.Errorf("got:\n%s\nwant one header row and IS-1, IS-2, IS-3 once each", out)
+	}
+}
+
+func TestNoSubtasksHidesSubtasks(t *testing.T) {
+	story, sub := sampleIssue("IS-1", "Open", 1), sampleIssue("IS-2", "Open", 0)
+	sub.Fields.IssueType.Subtask = true
+	// The fake honours subtaskIssueTypes() the way Jira would.
+	f := newFakeJira(t)
+	f.mux.HandleFunc("POST /rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ JQL string }
+		json.NewDecoder(r.Body).Decode(&body)
+		issues := []JiraIssue{story, sub}
+		if strings.Contains(body.JQL, "issuetype not in subtaskIssueTypes()") {
+			issues = issues[:1]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(searchResult(issues...))
+	})
+
+	for _, hide := range []bool{false, true} {
+		var err error
+		out := captureStdout(t, func() { err = listFlow(f.config(), listOptions{NoSubtasks: hide}) })
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Contains(out, "IS-2"); got == hide {
+			t.Errorf("-no-subtasks=%v: subtask listed = %v:\n%s", hide, got, out)
+		}
+		if !strings.Contains(out, "IS-1") {
+			t.Errorf("-no-subtasks=%v: story missing:\n%s", hide, out)
+		}
+	}
+}
+
+func TestExplainPrintsQueryWithoutSearching(t *testing.T) {
+	f := newFakeJira(t)
+	lo := listOptions{Explain: true, NoSubtasks: true, Epics: true, Category: "in-progress"}
+	lo.Team.Set("platform")
+
+	var err error
+	out := captureStdout(t, func() { err = listFlow(f.config(), lo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `JQL:    assignee in membersOf("platform") AND statusCategory = "indeterminate" AND issuetype not in subtaskIssueTypes()` + "\n" +
+		"Fields: " + strings.Join(searchFields, ",") + "\n"
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.reqs) != 0 {
+		t.Errorf("-explain made %d requests", len(f.reqs))
+	}
+}
+
+func TestDisplayLocation(t *testing.T) {
+	setGlobal(t, &time.Local, time.FixedZone("Machine", -5*3600))
+	setGlobal(t, &tzOverride, "")
+	setGlobal(t, &displayZone, nil)
+	f := newFakeJira(t)
+	f.reply("GET /rest/api/3/myself", 200, map[string]any{"accountId": "acc-1", "timeZone": "Asia/Tokyo"})
+	ts := time.Date(2024, 3, 1, 23, 30, 0, 0, time.UTC)
+
+	loc, err := displayLocation(f.config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := formatTime(ts, loc), "2024-03-02 08:30"; got != want {
+		t.Errorf("profile zone: %s, want %s", got, want)
+	}
+
+	displayZone = nil
+	tzOverride = "Europe/Berlin"
+	loc, err = displayLocation(f.config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := formatTime(ts, loc), "2024-03-02 00:30"; got != want {
+		t.Errorf("-tz zone: %s, want %s", got, want)
+	}
+
+	displayZone = nil
+	tzOverride = "Mars/Olympus"
+	if _, err := displayLocation(f.config()); !errors.Is(err, ErrUsage) {
+		t.Errorf("unknown zone: err = %v, want a usage error", err)
+	}
+}
+
+func TestCompactOnlyHeaders(t *testing.T) {
+	a, b, c := sampleIssue("IS-1", "Open", 1), sampleIssue("IS-2", "Open", 2), sampleIssue("IS-3", "Open", 3)
+	a.Fields.Sprints = []Sprint{{ID: 1, Name: "S1", State: "active"}}
+	b.Fields.Sprints = a.Fields.Sprints
+
+	out := formatIssuesBySprint([]JiraIssue{a, b, c}, formatOptions{Compact: true})
+	want := "Sprint: S1 (2 issues, 3 pts)\nSprint: Backlog (1 issues, 3 pts)"
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestBacklogCmd(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("POST /rest/agile/1.0/backlog/issue", 204, nil)
+	setGlobal(t, &skipPreflight, true)
+	cfg := f.config()
+	cfg.DefaultProject = "IS"
+
+	var err error
+	out := captureStdout(t, func() { err = backlogCmd(cfg, []string{"IS-1", "7"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs := f.requests("POST", "/rest/agile/1.0/backlog/issue")
+	if len(reqs) != 1 {
+		t.Fatalf("%d backlog requests, want 1", len(reqs))
+	}
+	if want := `{"issues":["IS-1","IS-7"]}`; reqs[0].Body != want {
+		t.Errorf("body = %s, want %s", reqs[0].Body, want)
+	}
+	if out != "Moved IS-1, IS-7 to the backlog\n" {
+		t.Errorf("output = %q", out)
+	}
+
+	// More than a few keys need a yes.
+	answerPrompts(t, "n")
+	many := []string{"1", "2", "3", "4", "5", "6"}
+	captureStdout(t, func() { err = backlogCmd(cfg, many) })
+	if err != nil || len(f.requests("POST", "/rest/agile/1.0/backlog/issue")) != 1 {
+		t.Errorf("declined move: err = %v, requests = %d", err, len(f.requests("POST", "/rest/agile/1.0/backlog/issue")))
+	}
+}
+
+func TestAPIURL(t *testing.T) {
+	q := url.Values{"fields": {"summary,status"}}
+	tests := []struct {
+		base  string
+		query url.Values
+		parts []string
+		want  string
+	}{
+		{"https://host", nil, []string{"rest/api/3/myself"}, "https://host/rest/api/3/myself"},
+		{"https://host/", nil, []string{"rest/api/3/myself"}, "https://host/rest/api/3/myself"},
+		{"https://host/jira", nil, []string{"rest/api/3/myself"}, "https://host/jira/rest/api/3/myself"},
+		{"https://host/jira/", nil, []string{"/rest/agile/1.0/sprint", "7", "issue"}, "https://host/jira/rest/agile/1.0/sprint/7/issue"},
+		{"https://host/jira", q, []string{"rest/api/3/issue", "IS-1"}, "https://host/jira/rest/api/3/issue/IS-1?fields=summary%2Cstatus"},
+	}
+	for _, tt := range tests {
+		if got := apiURL(JiraConfig{URL: tt.base}, tt.query, tt.parts...); got != tt.want {
+			t.Errorf("apiURL(%q, %v) = %s, want %s", tt.base, tt.parts, got, tt.want)
+		}
+	}
+}
+
+func TestEndpointsUnderContextPath(t *testing.T) {
+	f := newFakeJira(t)
+	f.mux.HandleFunc("/jira/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/components") {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`{"isLast": true}`))
+	})
+	cfg := f.config()
+	cfg.URL = f.URL + "/jira/"
+
+	calls := []func() error{
+		func() error { _, err := searchIssues(cfg, "project = X"); return err },
+		func() error { _, err := getMyself(cfg); return err },
+		func() error { _, err := getIssue(cfg, "IS-1", "summary"); return err },
+		func() error { _, err := getTransitions(cfg, "IS-1"); return err },
+		func() error { return addIssuesToSprint(cfg, 7, []string{"IS-1"}) },
+		func() error { return moveIssuesToBacklog(cfg, []string{"IS-1"}) },
+		func() error { _, err := getProjectComponents(cfg, "IS"); return err },
+		func() error {
+			return boardIssuesEach(cfg, boardIssuesPath(3, 0), "", func([]JiraIssue) error { return nil })
+		},
+	}
+	for i, call := range calls {
+		if err := call(); err != nil {
+			t.Errorf("call %d: %v", i, err)
+		}
+	}
+	want := []string{
+		"POST /jira/rest/api/3/search/jql",
+		"GET /jira/rest/api/3/myself",
+		"GET /jira/rest/api/3/issue/IS-1",
+		"GET /jira/rest/api/3/issue/IS-1/transitions",
+		"POST /jira/rest/agile/1.0/sprint/7/issue",
+		"POST /jira/rest/agile/1.0/backlog/issue",
+		"GET /jira/rest/api/3/project/IS/components",
+		"GET /jira/rest/agile/1.0/board/3/issue",
+	}
+	var got []string
+	for _, r := range f.reqs {
+		got = append(got, r.Method+" "+r.Path)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("requests:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestSprintNameLatestClosed(t *testing.T) {
+	closed := func(name, end string) Sprint {
+		return Sprint{Name: name, State: "closed", EndDate: end}
+	}
+	tests := []struct {
+		name    string
+		sprints []Sprint
+		want    string
+	}{
+		{"latest end date wins over array order", []Sprint{
+			closed("Sprint 3", "2024-03-01T00:00:00.000+0000"),
+			closed("Sprint 1", "2024-01-01T00:00:00.000+0000"),
+			closed("Sprint 2", "2024-02-01T00:00:00.000+0000"),
+		}, "Sprint 3"},
+		{"active wins", []Sprint{
+			closed("Sprint 3", "2024-03-01T00:00:00.000+0000"),
+			{Name: "Sprint 4", State: "active"},
+		}, "Sprint 4"},
+		{"undated future after dated closed", []Sprint{
+			{Name: "Next", State: "future"},
+			closed("Sprint 3", "2024-03-01T00:00:00.000+0000"),
+		}, "Next"},
+		{"undated closed before dated closed", []Sprint{
+			closed("Sprint 2", "2024-02-01T00:00:00.000+0000"),
+			closed("Old", ""),
+		}, "Sprint 2"},
+		{"no sprints", nil, "Backlog"},
+	}
+	for _, tt := range tests {
+		if got := sprintName(tt.sprints); got != tt.want {
+			t.Errorf("%s: sprintName = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGroupClosedAs(t *testing.T) {
+	a, b, c := sampleIssue("IS-1", "Open", 1), sampleIssue("IS-2", "Open", 2), sampleIssue("IS-3", "Open", 3)
+	a.Fields.Sprints = []Sprint{{ID: 1, Name: "Sprint 1", State: "closed"}}
+	b.Fields.Sprints = []Sprint{{ID: 2, Name: "Sprint 2", State: "closed"}}
+	c.Fields.Sprints = []Sprint{{ID: 3, Name: "Sprint 3", State: "active"}}
+
+	out := formatIssuesBySprint([]JiraIssue{a, b, c}, formatOptions{Compact: true, GroupClosedAs: "Closed"})
+	want := "Sprint: Sprint 3 (1 issues, 3 pts)\nSprint: Closed (2 issues, 3 pts)"
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestAssigneeColumn(t *testing.T) {
+	var issues []JiraIssue
+	err := json.Unmarshal([]byte(`[
+		{"key": "IS-1", "fields": {"summary": "Mine", "assignee": {"accountId": "acc-1", "displayName": "Ada Lovelace"}}},
+		{"key": "IS-2", "fields": {"summary": "Nobody's", "assignee": null}}
+	]`), &issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := formatFlat(issues, formatOptions{Columns: []string{"key", "assignee"}})
+	if want := "KEY\tASSIGNEE\nIS-1\tAda Lovelace\nIS-2\t-"; out != want {
+		t.Errorf("got:\n%q\nwant:\n%q", out, want)
+	}
+	if !slices.Contains(searchFields, "assignee") {
+		t.Errorf("searchFields %v lack assignee", searchFields)
+	}
+}
+
+func TestTransitionFromGuard(t *testing.T) {
+	f := newFakeJira(t)
+	f.replyTransitions("In Review:indeterminate")
+	f.reply("GET /rest/api/3/issue/IS-1", 200, map[string]any{"key": "IS-1", "fields": map[string]any{"status": map[string]any{"name": "Open"}}})
+	f.reply("GET /rest/api/3/issue/IS-2", 200, map[string]any{"key": "IS-2", "fields": map[string]any{"status": map[string]any{"name": "In Progress"}}})
+
+	var err error
+	out := captureStdout(t, func() { err = transitionCmd(f.config(), []string{"-from", "in-progress,Blocked", "IS-1", "In Review"}) })
+	if err != nil {
+		t.Fatalf("skip should not be an error: %v", err)
+	}
+	if want := "Skipped IS-1: status is \"Open\", not in-progress or Blocked\n"; out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+	if got := f.transitioned(); len(got) != 0 {
+		t.Errorf("transitioned %q despite the guard", got)
+	}
+
+	captureStdout(t, func() {
+		err = transitionCmd(f.config(), []string{"-from", "Blocked", "-from", "In Progress", "IS-2", "In Review"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.transitioned(); !slices.Equal(got, []string{"IS-2"}) {
+		t.Errorf("transitioned %q, want IS-2", got)
+	}
+}
+
+func TestMoveSprintID(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("POST /rest/agile/1.0/sprint/{id}/issue", 204, nil)
+	setGlobal(t, &skipPreflight, true)
+
+	var err error
+	out := captureStdout(t, func() { err = moveCmd(f.config(), []string{"-sprint-id", "4821", "IS-1"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs := f.requests("POST", "/rest/agile/1.0/sprint/4821/issue")
+	if len(reqs) != 1 || reqs[0].Body != `{"issues":["IS-1"]}` {
+		t.Errorf("requests = %+v", reqs)
+	}
+	if out != "Added IS-1 to sprint 4821\n" {
+		t.Errorf("output = %q", out)
+	}
+	// No search for an active sprint was needed.
+	if n := len(f.requests("POST", searchJQLPath)); n != 0 {
+		t.Errorf("%d searches, want 0", n)
+	}
+
+	for _, id := range []string{"0", "-3", "abc", "1.5"} {
+		if err := moveCmd(f.config(), []string{"-sprint-id", id, "IS-1"}); !errors.Is(err, ErrUsage) {
+			t.Errorf("-sprint-id %s: err = %v, want a usage error", id, err)
+		}
+	}
+}
+
+func TestMoveWithoutSprintFallback(t *testing.T) {
+	f := newFakeJira(t)
+	setGlobal(t, &noSprintFallback, false)
+	for _, args := range [][]string{{"-no-active-sprint-fallback", "IS-1"}, {"-no-active-sprint-fallback", "-all", "-yes"}} {
+		if err := moveCmd(f.config(), args); !errors.Is(err, errNoSprintFallback) {
+			t.Errorf("%q: err = %v, want errNoSprintFallback", args, err)
+		}
+	}
+	if _, err := findActiveSprint([]JiraIssue{sampleIssue("IS-1", "Open", 1)}); !errors.Is(err, errNoSprintFallback) {
+		t.Errorf("findActiveSprint: err = %v, want errNoSprintFallback", err)
+	}
+	f.mu.Lock()
+	n := len(f.reqs)
+	f.mu.Unlock()
+	if n != 0 {
+		t.Errorf("%d requests made before refusing", n)
+	}
+
+	// An explicit sprint is still fine.
+	f.reply("POST /rest/agile/1.0/sprint/5/issue", 204, nil)
+	setGlobal(t, &skipPreflight, true)
+	var err error
+	captureStdout(t, func() { err = moveCmd(f.config(), []string{"-no-active-sprint-fallback", "-sprint-id", "5", "IS-1"}) })
+	if err != nil {
+		t.Errorf("with -sprint-id: %v", err)
+	}
+}
+
+func TestFindActiveSprintAmbiguous(t *testing.T) {
+	setGlobal(t, &noSprintFallback, false)
+	a, b := sampleIssue("WEB-1", "Open", 1), sampleIssue("API-1", "Open", 1)
+	a.Fields.Sprints = []Sprint{{ID: 11, Name: "Web 4", State: "active"}}
+	b.Fields.Sprints = []Sprint{{ID: 22, Name: "API 9", State: "active"}}
+	issues := []JiraIssue{a, b}
+
+	setGlobal(t, &promptIsTerminal, func() bool { return false })
+	_, err := findActiveSprint(issues)
+	if !errors.Is(err, ErrUsage) {
+		t.Fatalf("err = %v, want a usage error", err)
+	}
+	if want := "your issues are in 2 active sprints: Web 4 (id 11), API 9 (id 22); pass move -sprint-id"; !strings.Contains(err.Error(), want) {
+		t.Errorf("err = %q, want it to contain %q", err, want)
+	}
+
+	// On a terminal the user picks one.
+	answerPrompts(t, "2")
+	var sp *Sprint
+	captureStdout(t, func() { sp, err = findActiveSprint(issues) })
+	if err != nil || sp == nil || sp.ID != 22 {
+		t.Errorf("picked %+v, %v; want sprint 22", sp, err)
+	}
+
+	// One active sprint, even on several issues, is not ambiguous.
+	b.Fields.Sprints = a.Fields.Sprints
+	if sp, err := findActiveSprint([]JiraIssue{a, b}); err != nil || sp.ID != 11 {
+		t.Errorf("single sprint: %+v, %v", sp, err)
+	}
+}
+
+func TestSelectKeyBypassesPicker(t *testing.T) {
+	f := newFakeJira(t)
+	listed := sampleIssue("IS-1", "Open", 0)
+	f.reply("POST /rest/api/3/search/jql", 200, searchResult(listed))
+	f.reply("GET /rest/api/3/issue/IS-9", 200, sampleIssue("IS-9", "Open", 0))
+	// No terminal and nothing to read: any prompt would fail.
+	setGlobal(t, &promptIsTerminal, func() bool { return false })
+	setGlobal[io.Reader](t, &stdin, strings.NewReader(""))
+	setGlobal(t, &promptAnswers, nil)
+
+	for _, key := range []string{"is-1", "IS-9"} {
+		setGlobal(t, &selectKey, key)
+		var got *JiraIssue
+		var err error
+		out := captureStdout(t, func() { got, err = selectIssue(f.config(), nil, "Select issue") })
+		if err != nil || got == nil || !strings.EqualFold(got.Key, key) {
+			t.Fatalf("selectIssue with -key %s = %v, %v", key, got, err)
+		}
+		if out != "" {
+			t.Errorf("-key %s printed the picker:\n%s", key, out)
+		}
+	}
+	if n := len(f.requests("GET", "/rest/api/3/issue/IS-9")); n != 1 {
+		t.Errorf("%d fetches of the unlisted key, want 1", n)
+	}
+	if n := len(f.requests("GET", "/rest/api/3/issue/IS-1")); n != 0 {
+		t.Errorf("fetched a key that was already listed")
+	}
+
+	setGlobal(t, &selectKey, "IS-1")
+	if _, err := selectIssue(f.config(), func(JiraIssue) bool { return false }, "Select issue"); !errors.Is(err, ErrUsage) {
+		t.Errorf("-key for a filtered-out issue: err = %v, want a usage error", err)
+	}
+}
+
+func TestDoJSONRejectsHTML(t *testing.T) {
+	f := newFakeJira(t)
+	f.mux.HandleFunc("GET /rest/api/3/myself", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte("<html><body>Log in</body></html>"))
+	})
+
+	_, err := getMyself(f.config())
+	if err == nil {
+		t.Fatal("no error for an HTML login page")
+	}
+	for _, want := range []string{"expected JSON but got text/html", "200 OK", "are your credentials valid?"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+	var syntax *json.SyntaxError
+	if errors.As(err, &syntax) {
+		t.Errorf("error is still a JSON syntax error: %v", err)
+	}
+}
+
+func TestBacklogOnDoneOnlyForDoneCategory(t *testing.T) {
+	f := newFakeJira(t)
+	f.replyTransitions("In Progress:indeterminate", "Shipped:done")
+	f.reply("POST /rest/agile/1.0/backlog/issue", 204, nil)
+	setGlobal(t, &skipPreflight, true)
+	setGlobal(t, &backlogOnDone, false)
+
+	var err error
+	out := captureStdout(t, func() { err = transitionCmd(f.config(), []string{"-backlog-on-done", "IS-1", "In Progress"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.requests("POST", "/rest/agile/1.0/backlog/issue")); n != 0 || strings.Contains(out, "backlog") {
+		t.Errorf("backlogged after an in-progress transition (%d requests):\n%s", n, out)
+	}
+
+	out = captureStdout(t, func() { err = transitionCmd(f.config(), []string{"-backlog-on-done", "IS-2", "Shipped"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs := f.requests("POST", "/rest/agile/1.0/backlog/issue")
+	if len(reqs) != 1 || reqs[0].Body != `{"issues":["IS-2"]}` {
+		t.Errorf("backlog requests = %+v, want one for IS-2", reqs)
+	}
+	if !strings.Contains(out, "Moved IS-2 to the backlog") {
+		t.Errorf("output:\n%s", out)
+	}
+
+	// Without the flag a Done transition stays in its sprint.
+	backlogOnDone = false
+	captureStdout(t, func() { err = transitionCmd(f.config(), []string{"IS-3", "Shipped"}) })
+	if err != nil || len(f.requests("POST", "/rest/agile/1.0/backlog/issue")) != 1 {
+		t.Errorf("backlogged without -backlog-on-done: err = %v", err)
+	}
+}
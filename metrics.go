@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// metricQueries returns the config file's [metrics] section: a name for
+// each JQL query whose issue count metrics reports.
+//
+//	[metrics]
+//	open = project = ABC AND statusCategory != Done
+//	blocked = project = ABC AND labels = blocked
+func metricQueries(c fileConfig) map[string]string {
+	return c.Section("metrics")
+}
+
+// countIssues counts the issues matching jql page by page, without
+// holding them all.
+func countIssues(cfg JiraConfig, jql string) (int, error) {
+	n := 0
+	err := searchIssuesEach(cfg, jql, func(page []JiraIssue) error {
+		n += len(page)
+		return nil
+	})
+	return n, err
+}
+
+var promLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// writeMetrics renders counts in the Prometheus text exposition format,
+// one jira_issues gauge per query, sorted by name.
+func writeMetrics(w io.Writer, counts map[string]int) error {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	var b bytes.Buffer
+	b.WriteString("# HELP jira_issues Number of issues matching each configured JQL query.\n")
+	b.WriteString("# TYPE jira_issues gauge\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "jira_issues{query=\"%s\"} %d\n", promLabelEscaper.Replace(name), counts[name])
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// writeFileAtomic replaces path in one rename, so a textfile collector
+// never reads a half-written file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func metricsCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("metrics")
+	out := fs.String("out", "", "write to this file instead of stdout, replacing it atomically")
+	if pos := parseArgs(fs, args); len(pos) > 0 {
+		return usageError("usage: jira-cli metrics [-out FILE]")
+	}
+	queries := metricQueries(cfg.File)
+	if len(queries) == 0 {
+		return usageError("no queries to count; add a [metrics] section to %s, e.g. open = statusCategory != Done", orDash(configPath()))
+	}
+
+	// Any failed query fails the run, leaving an earlier -out file in
+	// place rather than publishing partial numbers.
+	counts := map[string]int{}
+	for name, jql := range queries {
+		n, err := countIssues(cfg, jql)
+		if err != nil {
+			return fmt.Errorf("metrics query %s: %w", name, err)
+		}
+		counts[name] = n
+	}
+
+	if *out == "" {
+		return writeMetrics(os.Stdout, counts)
+	}
+	var b bytes.Buffer
+	if err := writeMetrics(&b, counts); err != nil {
+		return err
+	}
+	return writeFileAtomic(*out, b.Bytes())
+}
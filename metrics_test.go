@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// promSample matches a sample line of the text exposition format with a
+// single label.
+var promSample = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*\{[a-zA-Z_][a-zA-Z0-9_]*="(?:[^"\\\n]|\\.)*"\} -?[0-9]+$`)
+
+func TestWriteMetrics(t *testing.T) {
+	var b strings.Builder
+	if err := writeMetrics(&b, map[string]int{"open": 12, "blocked": 3, `odd "name"`: 0}); err != nil {
+		t.Fatal(err)
+	}
+	want := `# HELP jira_issues Number of issues matching each configured JQL query.
+# TYPE jira_issues gauge
+jira_issues{query="blocked"} 3
+jira_issues{query="odd \"name\""} 0
+jira_issues{query="open"} 12
+`
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n") {
+		if !strings.HasPrefix(line, "# ") && !promSample.MatchString(line) {
+			t.Errorf("not a valid sample line: %q", line)
+		}
+	}
+}
+
+func TestMetricsCmdWritesFile(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("POST /rest/api/3/search/jql", 200, searchResult(sampleIssue("IS-1", "Open", 0), sampleIssue("IS-2", "Open", 0)))
+	cfg := f.config()
+	var err error
+	cfg.File, err = parseConfig(strings.NewReader("[metrics]\nopen = statusCategory != Done\nin_review = status = \"In Review\"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(t.TempDir(), "jira.prom")
+	if err := metricsCmd(cfg, []string{"-out", out}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`jira_issues{query="in_review"} 2`, `jira_issues{query="open"} 2`} {
+		if !strings.Contains(string(got), want+"\n") {
+			t.Errorf("metrics file lacks %s:\n%s", want, got)
+		}
+	}
+	if _, err := os.Stat(out + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// openBrowser launches the system browser on u. It is a variable so the
+// launch can be replaced when running without a desktop.
+var openBrowser = func(u string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", u)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+	default:
+		cmd = exec.Command("xdg-open", u)
+	}
+	return cmd.Start()
+}
+
+// openAfter makes transition and move open the issue once they succeed.
+var openAfter bool
+
+func browseURL(cfg JiraConfig, key string) string {
+	return apiURL(cfg, nil, "browse", url.PathEscape(key))
+}
+
+// stdoutIsTerminal decides whether openIssue launches a browser at all.
+var stdoutIsTerminal = func() bool { return isTerminal(os.Stdout) }
+
+// openIssue opens key in the browser, or only prints its URL when stdout
+// is not a terminal or no browser could be started.
+func openIssue(cfg JiraConfig, key string) {
+	u := browseURL(cfg, key)
+	if !stdoutIsTerminal() {
+		fmt.Println(u)
+		return
+	}
+	if err := openBrowser(u); err != nil {
+		debugf("opening browser: %v", err)
+		fmt.Println(u)
+	}
+}
+
+func openCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("open")
+	fromBranch := fs.Bool("branch", false, "take the issue key from the current git branch")
+	pos := parseArgs(fs, args)
+	if *fromBranch {
+		if len(pos) > 0 {
+			return usageError("-branch does not take an issue key")
+		}
+		k, err := branchKey(cfg)
+		if err != nil {
+			return err
+		}
+		pos = []string{k}
+	}
+	if len(pos) != 1 {
+		return usageError("usage: jira-cli open <KEY|-branch>")
+	}
+	openIssue(cfg, expandKey(cfg, pos[0]))
+	return nil
+}
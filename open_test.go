@@ -0,0 +1,66 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// recordBrowser makes openBrowser record the URLs it is asked to open.
+func recordBrowser(t *testing.T) *[]string {
+	t.Helper()
+	var opened []string
+	setGlobal(t, &stdoutIsTerminal, func() bool { return true })
+	setGlobal(t, &openBrowser, func(u string) error {
+		opened = append(opened, u)
+		return nil
+	})
+	return &opened
+}
+
+func TestOpenAfterTransition(t *testing.T) {
+	f := newFakeJira(t)
+	f.replyTransitions("In Progress:indeterminate")
+	opened := recordBrowser(t)
+	setGlobal(t, &openAfter, false)
+
+	var err error
+	captureStdout(t, func() { err = transitionCmd(f.config(), []string{"-open-after", "IS-1", "In Progress"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{f.URL + "/browse/IS-1"}; !slices.Equal(*opened, want) {
+		t.Errorf("opened %q, want %q", *opened, want)
+	}
+}
+
+func TestOpenIssuePrintsURLWithoutTerminal(t *testing.T) {
+	opened := recordBrowser(t)
+	stdoutIsTerminal = func() bool { return false }
+	out := captureStdout(t, func() { openIssue(JiraConfig{URL: "https://example.atlassian.net"}, "IS-1") })
+	if len(*opened) != 0 {
+		t.Errorf("opened %q without a terminal", *opened)
+	}
+	if strings.TrimSpace(out) != "https://example.atlassian.net/browse/IS-1" {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestOpenAfterInteractiveFlow(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("POST /rest/api/3/search/jql", 200, searchResult(sampleIssue("IS-1", "Open", 1)))
+	f.replyTransitions("In Progress:indeterminate")
+	setGlobal(t, &skipPreflight, true)
+	setGlobal(t, &openAfter, true)
+	answerPrompts(t, "1", "2")
+	opened := recordBrowser(t)
+
+	var err error
+	captureStdout(t, func() { err = interactiveFlow(f.config(), false) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{f.URL + "/browse/IS-1"}; !slices.Equal(*opened, want) {
+		t.Errorf("opened %q, want %q", *opened, want)
+	}
+}
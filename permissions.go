@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// sprintPermissions are what moving an issue between sprints and the
+// backlog needs on its project.
+var sprintPermissions = []string{"SCHEDULE_ISSUES", "EDIT_ISSUES"}
+
+type permission struct {
+	Name           string `json:"name"`
+	HavePermission bool   `json:"havePermission"`
+}
+
+// missingPermissions lists the permissions in perms that are absent or
+// not granted, as "Name (KEY)".
+func missingPermissions(perms map[string]permission, want []string) []string {
+	var missing []string
+	for _, k := range want {
+		p, ok := perms[k]
+		if ok && p.HavePermission {
+			continue
+		}
+		if p.Name != "" {
+			missing = append(missing, fmt.Sprintf("%s (%s)", p.Name, k))
+		} else {
+			missing = append(missing, k)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// checkPermissions confirms the token holds want on issueKey, so an
+// operation fails upfront with the permission's name instead of a bare
+// 403 halfway through. It is best-effort: if the check itself can't be
+// made, the operation goes ahead. -skip-preflight turns it off.
+func checkPermissions(cfg JiraConfig, issueKey string, want ...string) error {
+	if skipPreflight {
+		return nil
+	}
+	var out struct {
+		Permissions map[string]permission `json:"permissions"`
+	}
+	q := url.Values{"permissions": {strings.Join(want, ",")}, "issueKey": {issueKey}}
+	if err := doJSON(cfg, http.MethodGet, apiURL(cfg, q, "rest/api/3/mypermissions"), nil, &out); err != nil {
+		if errors.Is(err, ErrAuth) {
+			return err
+		}
+		debugf("permission check skipped: %v", err)
+		return nil
+	}
+	if missing := missingPermissions(out.Permissions, want); len(missing) > 0 {
+		return fmt.Errorf("%w: missing permission %s on %s", ErrPermission, strings.Join(missing, ", "), issueKey)
+	}
+	return nil
+}
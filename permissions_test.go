@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckPermissionsMissing(t *testing.T) {
+	setGlobal(t, &skipPreflight, false)
+	f := newFakeJira(t)
+	f.reply("GET /rest/api/3/mypermissions", 200, map[string]any{"permissions": map[string]any{
+		"SCHEDULE_ISSUES": map[string]any{"name": "Schedule Issues", "havePermission": false},
+		"EDIT_ISSUES":     map[string]any{"name": "Edit Issues", "havePermission": true},
+	}})
+
+	err := checkPermissions(f.config(), "IS-1", sprintPermissions...)
+	if !errors.Is(err, ErrPermission) {
+		t.Fatalf("err = %v, want ErrPermission", err)
+	}
+	if want := "permission denied: missing permission Schedule Issues (SCHEDULE_ISSUES) on IS-1"; err.Error() != want {
+		t.Errorf("err = %q, want %q", err, want)
+	}
+	reqs := f.requests("GET", "/rest/api/3/mypermissions")
+	if len(reqs) != 1 || reqs[0].Query.Get("permissions") != "SCHEDULE_ISSUES,EDIT_ISSUES" || reqs[0].Query.Get("issueKey") != "IS-1" {
+		t.Errorf("requests = %+v", reqs)
+	}
+}
+
+func TestCheckPermissionsBestEffort(t *testing.T) {
+	setGlobal(t, &skipPreflight, false)
+	f := newFakeJira(t)
+	f.reply("GET /rest/api/3/mypermissions", 500, nil)
+	if err := checkPermissions(f.config(), "IS-1", sprintPermissions...); err != nil {
+		t.Errorf("a failed check should let the operation go ahead: %v", err)
+	}
+
+	skipPreflight = true
+	if err := checkPermissions(JiraConfig{URL: "http://127.0.0.1:0"}, "IS-1", "EDIT_ISSUES"); err != nil {
+		t.Errorf("-skip-preflight: %v", err)
+	}
+}
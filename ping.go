@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type pingResult struct {
+	OK        bool   `json:"ok"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ping times a round trip to /myself.
+func ping(cfg JiraConfig) (pingResult, error) {
+	start := time.Now()
+	_, err := getMyself(cfg)
+	res := pingResult{OK: err == nil, LatencyMS: time.Since(start).Milliseconds()}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	return res, err
+}
+
+func pingCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("ping")
+	asJSON := fs.Bool("json", false, "print the result as JSON")
+	parseArgs(fs, args)
+
+	// A cached answer would say nothing about the connection.
+	noCache = true
+	res, err := ping(cfg)
+	if *asJSON {
+		buf, _ := json.Marshal(res)
+		fmt.Println(string(buf))
+	} else if err == nil {
+		fmt.Printf("ok %dms\n", res.LatencyMS)
+	}
+	return err
+}
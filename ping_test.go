@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPingLatencyAndOK(t *testing.T) {
+	f := newFakeJira(t)
+	f.mux.HandleFunc("GET /rest/api/3/myself", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"accountId": "acc-1"}`))
+	})
+	setGlobal(t, &noCache, false)
+
+	var err error
+	out := captureStdout(t, func() { err = pingCmd(f.config(), []string{"-json"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res pingResult
+	if err := json.Unmarshal([]byte(out), &res); err != nil {
+		t.Fatalf("output %q: %v", out, err)
+	}
+	if !res.OK || res.LatencyMS < 20 {
+		t.Errorf("result = %+v, want ok with latency_ms >= 20", res)
+	}
+
+	down := newFakeJira(t)
+	down.reply("GET /rest/api/3/myself", 401, nil)
+	res, err = ping(down.config())
+	if !errors.Is(err, ErrAuth) || res.OK || res.Error == "" {
+		t.Errorf("ping on a 401 = %+v, %v; want not ok with ErrAuth", res, err)
+	}
+}
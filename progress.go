@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// progressOut receives pagination progress. main points it at stderr
+// when that is a terminal; nil keeps fetches silent.
+var progressOut io.Writer
+
+var spinnerFrames = []rune{'⠋', '⠙', '⠹', '⠸', '⠼', '⠴', '⠦', '⠧', '⠇', '⠏'}
+
+// progress draws a one-line spinner that is redrawn in place and wiped
+// by done, so nothing is left behind once results are printed.
+type progress struct {
+	w     io.Writer
+	page  int
+	shown bool
+}
+
+func newProgress() *progress {
+	return &progress{w: progressOut}
+}
+
+// next reports that another page is being fetched, with n issues so far.
+func (p *progress) next(n int) {
+	p.page++
+	if p.w == nil {
+		return
+	}
+	frame := spinnerFrames[(p.page-1)%len(spinnerFrames)]
+	fmt.Fprintf(p.w, "\r\x1b[K%c Fetching issues… (page %d, %d so far)", frame, p.page, n)
+	p.shown = true
+}
+
+func (p *progress) done() {
+	if p.shown {
+		fmt.Fprint(p.w, "\r\x1b[K")
+		p.shown = false
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestProgressStaysOffStdout(t *testing.T) {
+	f := newFakeJira(t)
+	f.mux.HandleFunc("POST /rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ NextPageToken string }
+		json.NewDecoder(r.Body).Decode(&body)
+		page := map[string]any{"issues": []JiraIssue{sampleIssue("IS-1", "Open", 1)}, "nextPageToken": "p2"}
+		if body.NextPageToken == "p2" {
+			page = searchResult(sampleIssue("IS-2", "Open", 1))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page)
+	})
+	var progressBuf strings.Builder
+	setGlobal[io.Writer](t, &progressOut, &progressBuf)
+
+	var err error
+	out := captureStdout(t, func() { err = listFlow(f.config(), listOptions{}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.ContainsAny(out, "\r\x1b"+string(spinnerFrames)) || strings.Contains(out, "Fetching") {
+		t.Errorf("spinner bytes on stdout:\n%q", out)
+	}
+	if !strings.Contains(out, "IS-1") || !strings.Contains(out, "IS-2") {
+		t.Errorf("listing lacks the issues:\n%s", out)
+	}
+	progress := progressBuf.String()
+	if !strings.Contains(progress, "Fetching issues… (page 2, 1 so far)") || !strings.HasSuffix(progress, "\r\x1b[K") {
+		t.Errorf("progress output = %q, want page 2 and a final clear", progress)
+	}
+}
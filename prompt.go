@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// promptTimeout is -prompt-timeout: how long a prompt waits for an
+// answer before cancelling. Zero waits forever.
+var promptTimeout time.Duration
+
+// promptIsTerminal reports whether prompts can be answered, replaceable
+// for testing.
+var promptIsTerminal = func() bool { return isTTY(os.Stdin) }
+
+var errNotTerminal = usageError("interactive flow requires a terminal")
+
+// answer is one line read for a prompt.
+type answer struct {
+	line string
+	err  error
+}
+
+// promptAnswers carries the lines of stdin to every prompt, read by one
+// goroutine for the life of the process. A line typed after a prompt
+// timed out goes to the next prompt instead of being lost.
+var promptAnswers chan answer
+
+// readLines sends each line of r to ch, closing it after the first
+// error.
+func readLines(r io.Reader, ch chan<- answer) {
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		ch <- answer{line, err}
+		if err != nil {
+			close(ch)
+			return
+		}
+	}
+}
+
+// readAnswer reads one line for a prompt. ok is false when
+// promptTimeout passes first. A line cut short by EOF is returned with
+// io.EOF.
+func readAnswer() (line string, ok bool, err error) {
+	if promptAnswers == nil {
+		promptAnswers = make(chan answer)
+		go readLines(stdin, promptAnswers)
+	}
+	var timeout <-chan time.Time
+	if promptTimeout > 0 {
+		timeout = time.After(promptTimeout)
+	}
+	select {
+	case a, open := <-promptAnswers:
+		if !open {
+			return "", true, io.EOF
+		}
+		return strings.TrimSpace(a.line), true, a.err
+	case <-timeout:
+		fmt.Fprintf(os.Stderr, "\nNo answer after %s, cancelled\n", promptTimeout)
+		return "", false, nil
+	}
+}
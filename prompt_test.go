@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInteractiveFlowRefusesWithoutTerminal(t *testing.T) {
+	f := newFakeJira(t)
+	setGlobal(t, &promptIsTerminal, func() bool { return false })
+
+	err := interactiveFlow(f.config(), false)
+	if !errors.Is(err, errNotTerminal) || !strings.Contains(err.Error(), "interactive flow requires a terminal") {
+		t.Errorf("err = %v, want errNotTerminal", err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.reqs) != 0 {
+		t.Errorf("sent %d requests before refusing", len(f.reqs))
+	}
+	if _, err := pickFromList("Select issue", []string{"IS-1"}); !errors.Is(err, errNotTerminal) {
+		t.Errorf("pickFromList err = %v, want errNotTerminal", err)
+	}
+}
+
+func TestPromptTimeoutCancels(t *testing.T) {
+	// The pipe is never written, so only the timeout can end the read.
+	r, w := io.Pipe()
+	t.Cleanup(func() { w.Close() })
+	setGlobal(t, &promptIsTerminal, func() bool { return true })
+	setGlobal[io.Reader](t, &stdin, r)
+	setGlobal(t, &promptAnswers, nil)
+	setGlobal(t, &promptTimeout, 20*time.Millisecond)
+
+	var idx int
+	var err error
+	var out string
+	msg := captureStderr(t, func() {
+		out = captureStdout(t, func() { idx, err = pickFromList("Select issue", []string{"IS-1", "IS-2"}) })
+	})
+	if idx != -1 || err != nil {
+		t.Errorf("pickFromList = %d, %v; want -1, nil", idx, err)
+	}
+	if !strings.Contains(msg, "No answer after 20ms, cancelled") {
+		t.Errorf("stderr lacks the cancellation message:\n%s", msg)
+	}
+	if strings.Contains(out, "No answer") {
+		t.Errorf("cancellation message went to stdout:\n%s", out)
+	}
+
+	// A line typed after the timeout answers the next prompt.
+	go w.Write([]byte("2\n"))
+	promptTimeout = time.Minute
+	captureStderr(t, func() {
+		captureStdout(t, func() { idx, err = pickFromList("Select issue", []string{"IS-1", "IS-2"}) })
+	})
+	if idx != 1 || err != nil {
+		t.Errorf("next prompt = %d, %v; want 1, nil", idx, err)
+	}
+}
+
+func TestPromptTimeoutAnswered(t *testing.T) {
+	answerPrompts(t, "2")
+	setGlobal(t, &promptTimeout, time.Minute)
+
+	var idx int
+	var err error
+	captureStdout(t, func() { idx, err = pickFromList("Select issue", []string{"IS-1", "IS-2"}) })
+	if idx != 1 || err != nil {
+		t.Errorf("pickFromList = %d, %v; want 1, nil", idx, err)
+	}
+}
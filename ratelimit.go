@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket that refills at rate tokens per second up
+// to burst. now and after are the clock, replaceable for testing.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	now   func() time.Time
+	after func(time.Duration) <-chan time.Time
+}
+
+func newRateLimiter(rate float64) *rateLimiter {
+	return &rateLimiter{rate: rate, burst: 1, tokens: 1, now: time.Now, after: time.After}
+}
+
+// wait blocks until a request may be sent or ctx is done. Each call takes
+// a token up front, so concurrent callers queue behind each other rather
+// than all waking at once.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens--
+	var d time.Duration
+	if l.tokens < 0 {
+		d = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if d == 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		// Hand the token back so cancelled waits don't slow later ones.
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-l.after(d):
+		return nil
+	}
+}
+
+// limiter paces doJSON; nil means unlimited. Set from JIRA_RATE_LIMIT.
+var limiter *rateLimiter
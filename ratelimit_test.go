@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeClock stands in for rateLimiter's clock: after records each wait
+// and fires at once without moving the time.
+type fakeClock struct {
+	t     time.Time
+	waits []time.Duration
+}
+
+func (c *fakeClock) install(l *rateLimiter) {
+	l.now = func() time.Time { return c.t }
+	l.after = func(d time.Duration) <-chan time.Time {
+		c.waits = append(c.waits, d)
+		ch := make(chan time.Time, 1)
+		ch <- c.t
+		return ch
+	}
+}
+
+func TestRateLimiterWaits(t *testing.T) {
+	l := newRateLimiter(2)
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	clock.install(l)
+
+	for range 3 {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want := []time.Duration{500 * time.Millisecond, time.Second}; !slices.Equal(clock.waits, want) {
+		t.Errorf("waits = %v, want %v", clock.waits, want)
+	}
+
+	// After a quiet spell the bucket is full again, but holds only one.
+	clock.t = clock.t.Add(10 * time.Second)
+	clock.waits = nil
+	l.wait(context.Background())
+	l.wait(context.Background())
+	if want := []time.Duration{500 * time.Millisecond}; !slices.Equal(clock.waits, want) {
+		t.Errorf("after idling: waits = %v, want %v", clock.waits, want)
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	l := newRateLimiter(1)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	l.after = func(time.Duration) <-chan time.Time { return nil }
+
+	l.wait(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if l.tokens != 0 {
+		t.Errorf("tokens = %v after a cancelled wait, want 0", l.tokens)
+	}
+}
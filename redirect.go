@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+)
+
+// noFollowRedirects is -no-follow-redirects: stop at the first redirect
+// and report it instead of following it.
+var noFollowRedirects bool
+
+// maxRedirects matches net/http's own limit.
+const maxRedirects = 10
+
+// checkRedirect is httpClient's CheckRedirect. net/http drops the
+// Authorization header on some redirects a reverse proxy makes, which
+// then surface as 401s, so it is put back for redirects to the same host
+// that don't downgrade https to http. Each redirect is logged with -v.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if noFollowRedirects {
+		return http.ErrUseLastResponse
+	}
+	if len(via) >= maxRedirects {
+		return errors.New("stopped after 10 redirects")
+	}
+	first := via[0]
+	debugf("redirect: %s %s -> %s", req.Response.Status, via[len(via)-1].URL.Redacted(), req.URL.Redacted())
+	sameHost := req.URL.Host == first.URL.Host && !(first.URL.Scheme == "https" && req.URL.Scheme == "http")
+	if auth := first.Header.Get("Authorization"); sameHost && auth != "" && req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", auth)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedirectKeepsAuthorizationOnSameHost(t *testing.T) {
+	f := newFakeJira(t)
+	f.mux.HandleFunc("GET /old/rest/api/3/myself", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/rest/api/3/myself", http.StatusMovedPermanently)
+	})
+	f.reply("GET /rest/api/3/myself", 200, map[string]any{"accountId": "acc-1"})
+	cfg := f.config()
+	cfg.URL += "/old"
+
+	if _, err := getMyself(cfg); err != nil {
+		t.Fatal(err)
+	}
+	reqs := f.requests("GET", "/rest/api/3/myself")
+	if len(reqs) != 1 {
+		t.Fatalf("%d requests after the redirect, want 1", len(reqs))
+	}
+	if user, _, ok := (&http.Request{Header: reqs[0].Header}).BasicAuth(); !ok || user != "me@example.com" {
+		t.Errorf("redirected request lost its Authorization: %v", reqs[0].Header)
+	}
+}
+
+func TestRedirectNotFollowed(t *testing.T) {
+	f := newFakeJira(t)
+	f.mux.HandleFunc("GET /old/rest/api/3/myself", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/rest/api/3/myself", http.StatusFound)
+	})
+	setGlobal(t, &noFollowRedirects, true)
+	cfg := f.config()
+	cfg.URL += "/old"
+
+	_, err := getMyself(cfg)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusFound || !strings.Contains(err.Error(), "not followed") {
+		t.Errorf("err = %v, want the redirect reported", err)
+	}
+	if n := len(f.requests("GET", "/rest/api/3/myself")); n != 0 {
+		t.Errorf("followed the redirect with -no-follow-redirects")
+	}
+}
+
+func TestCheckRedirectAuthorization(t *testing.T) {
+	newReq := func(url string) *http.Request {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		req.Response = &http.Response{Status: "301 Moved Permanently"}
+		return req
+	}
+	first := newReq("https://jira.example.com/rest/api/3/myself")
+	first.Header.Set("Authorization", "Basic c2VjcmV0")
+
+	tests := []struct {
+		url  string
+		keep bool
+	}{
+		{"https://jira.example.com/jira/rest/api/3/myself", true},
+		{"http://jira.example.com/rest/api/3/myself", false},
+		{"https://sso.example.com/login", false},
+	}
+	for _, tt := range tests {
+		req := newReq(tt.url)
+		if err := checkRedirect(req, []*http.Request{first}); err != nil {
+			t.Fatal(err)
+		}
+		if got := req.Header.Get("Authorization") != ""; got != tt.keep {
+			t.Errorf("redirect to %s: Authorization kept = %v, want %v", tt.url, got, tt.keep)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxRetries is how often a request is resent after a retryable status.
+const maxRetries = 3
+
+// retryAfter is the clock for backing off, replaceable for testing.
+var retryAfter = time.After
+
+// retryable reports whether a request that got status may be resent.
+// 429 means the request was refused unprocessed, so any method can
+// retry; a gateway error may have happened after a POST took effect, so
+// those only retry idempotent methods.
+func retryable(method string, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return method != http.MethodPost
+	}
+	return false
+}
+
+// retryDelay honors a Retry-After in seconds and otherwise backs off
+// 1s, 2s, 4s.
+func retryDelay(res *http.Response, attempt int) time.Duration {
+	if s, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && s >= 0 {
+		return min(time.Duration(s)*time.Second, time.Minute)
+	}
+	return time.Second << attempt
+}
+
+// retryStats tallies requests and the statuses that were retried, for
+// -retry-summary.
+type retryStats struct {
+	mu       sync.Mutex
+	requests int
+	retries  map[int]int
+}
+
+var retries retryStats
+
+// retrySummary is -retry-summary: print the tally after the command.
+var retrySummary bool
+
+func (s *retryStats) request() {
+	s.mu.Lock()
+	s.requests++
+	s.mu.Unlock()
+}
+
+func (s *retryStats) retried(status int) {
+	s.mu.Lock()
+	if s.retries == nil {
+		s.retries = map[int]int{}
+	}
+	s.retries[status]++
+	s.mu.Unlock()
+}
+
+// String renders the tally, e.g. "3 requests, 2 retries (1×429, 1×503)".
+func (s *retryStats) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	total := 0
+	var parts []string
+	codes := make([]int, 0, len(s.retries))
+	for c := range s.retries {
+		codes = append(codes, c)
+	}
+	slices.Sort(codes)
+	for _, c := range codes {
+		total += s.retries[c]
+		parts = append(parts, fmt.Sprintf("%d×%d", s.retries[c], c))
+	}
+	out := fmt.Sprintf("%d %s, %d %s", s.requests, plural(s.requests, "request"), total, plural(total, "retry"))
+	if len(parts) > 0 {
+		out += " (" + strings.Join(parts, ", ") + ")"
+	}
+	return out
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	if strings.HasSuffix(word, "y") {
+		return strings.TrimSuffix(word, "y") + "ies"
+	}
+	return word + "s"
+}
+
+// printRetrySummary writes the tally to stderr under -retry-summary or
+// -v, once the command is done either way.
+func printRetrySummary() {
+	retries.mu.Lock()
+	n := retries.requests
+	retries.mu.Unlock()
+	if n > 0 && (retrySummary || verbose) {
+		fmt.Fprintln(os.Stderr, "jira-cli: "+retries.String())
+	}
+}
+
+// sendWithRetry sends req, resending it after a retryable status up to
+// maxRetries times. The body is replayed through req.GetBody, so requests
+// built from a bytes reader or buffer can always be retried.
+func sendWithRetry(req *http.Request) (*http.Response, error) {
+	retries.request()
+	for attempt := 0; ; attempt++ {
+		if limiter != nil {
+			if err := limiter.wait(rootCtx); err != nil {
+				return nil, err
+			}
+		}
+		res, err := httpClient.Do(req)
+		if err != nil || attempt == maxRetries || !retryable(req.Method, res.StatusCode) ||
+			(req.Body != nil && req.GetBody == nil) {
+			return res, err
+		}
+
+		d := retryDelay(res, attempt)
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+		retries.retried(res.StatusCode)
+		debugf("%s %s: %s, retrying in %s", req.Method, req.URL.Path, res.Status, d)
+		select {
+		case <-rootCtx.Done():
+			return nil, rootCtx.Err()
+		case <-retryAfter(d):
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
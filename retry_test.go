@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+// resetRetries clears the global tally for the test.
+func resetRetries(t *testing.T) {
+	t.Helper()
+	reset := func() {
+		retries.mu.Lock()
+		retries.requests, retries.retries = 0, nil
+		retries.mu.Unlock()
+	}
+	reset()
+	t.Cleanup(reset)
+}
+
+// scriptStatuses answers pattern with each status in turn, then 200s.
+func (f *fakeJira) scriptStatuses(pattern string, statuses ...int) {
+	f.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		status := http.StatusOK
+		if len(statuses) > 0 {
+			status, statuses = statuses[0], statuses[1:]
+		}
+		f.mu.Unlock()
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "2")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(`{}`))
+	})
+}
+
+func TestRetryTally(t *testing.T) {
+	resetRetries(t)
+	var waits []time.Duration
+	setGlobal(t, &retryAfter, func(d time.Duration) <-chan time.Time {
+		waits = append(waits, d)
+		ch := make(chan time.Time, 1)
+		ch <- time.Time{}
+		return ch
+	})
+	f := newFakeJira(t)
+	f.scriptStatuses("GET /rest/api/3/myself", 429, 503, 503)
+	f.scriptStatuses("POST /rest/api/3/issue", 503)
+
+	if _, err := getMyself(f.config()); err != nil {
+		t.Fatalf("after retries: %v", err)
+	}
+	if n := len(f.requests("GET", "/rest/api/3/myself")); n != 4 {
+		t.Errorf("%d attempts, want 4", n)
+	}
+	// Retry-After first, then the 2s and 4s backoff of attempts 1 and 2.
+	if want := []time.Duration{2 * time.Second, 2 * time.Second, 4 * time.Second}; !slices.Equal(waits, want) {
+		t.Errorf("waits = %v, want %v", waits, want)
+	}
+
+	// A POST is not resent after a gateway error.
+	_, err := createIssue(f.config(), map[string]any{"summary": "x"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 503 {
+		t.Errorf("POST: err = %v, want the 503", err)
+	}
+	if n := len(f.requests("POST", "/rest/api/3/issue")); n != 1 {
+		t.Errorf("POST sent %d times, want 1", n)
+	}
+
+	if got, want := retries.String(), "2 requests, 3 retries (1×429, 2×503)"; got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+}
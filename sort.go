@@ -0,0 +1,83 @@
+package main
+
+import (
+	"cmp"
+	"sort"
+	"strings"
+)
+
+type sortKey struct {
+	Field string
+	Desc  bool
+}
+
+// sortCompare compares two issues on one field, ascending.
+var sortCompare = map[string]func(a, b JiraIssue) int{
+	"key": func(a, b JiraIssue) int {
+		switch {
+		case keyLess(a.Key, b.Key):
+			return -1
+		case keyLess(b.Key, a.Key):
+			return 1
+		}
+		return 0
+	},
+	"points": func(a, b JiraIssue) int { return cmp.Compare(a.Fields.Points, b.Fields.Points) },
+	"status": func(a, b JiraIssue) int {
+		return cmp.Compare(strings.ToLower(a.Fields.Status.Name), strings.ToLower(b.Fields.Status.Name))
+	},
+	"type": func(a, b JiraIssue) int {
+		return cmp.Compare(strings.ToLower(a.Fields.IssueType.Name), strings.ToLower(b.Fields.IssueType.Name))
+	},
+	"updated": func(a, b JiraIssue) int {
+		ta, _ := parseJiraTime(a.Fields.Updated)
+		tb, _ := parseJiraTime(b.Fields.Updated)
+		return ta.Compare(tb)
+	},
+}
+
+// parseSortSpec parses -sort values like "points:desc,key:asc". The
+// direction defaults to asc.
+func parseSortSpec(spec string) ([]sortKey, error) {
+	var keys []sortKey
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		field, dir, _ := strings.Cut(part, ":")
+		field = strings.ToLower(strings.TrimSpace(field))
+		if sortCompare[field] == nil {
+			return nil, usageError("unknown sort field %q (valid: key, points, status, type, updated)", field)
+		}
+		k := sortKey{Field: field}
+		switch strings.ToLower(strings.TrimSpace(dir)) {
+		case "", "asc":
+		case "desc":
+			k.Desc = true
+		default:
+			return nil, usageError("unknown sort direction %q for %s (want asc or desc)", dir, field)
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return nil, usageError("-sort needs at least one field")
+	}
+	return keys, nil
+}
+
+// sortIssues stably sorts issues by keys, earlier keys taking precedence.
+func sortIssues(issues []JiraIssue, keys []sortKey) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		for _, k := range keys {
+			c := sortCompare[k.Field](issues[i], issues[j])
+			if k.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSortIssues(t *testing.T) {
+	issues := []JiraIssue{
+		sampleIssue("IS-10", "Open", 3),
+		sampleIssue("IS-2", "Done", 5),
+		sampleIssue("IS-9", "In Progress", 3),
+		sampleIssue("IS-1", "open", 1),
+	}
+	issues[0].Fields.Updated = "2024-01-03T10:00:00.000+0000"
+	issues[1].Fields.Updated = "2024-01-01T10:00:00.000+0000"
+	issues[2].Fields.Updated = "2024-01-02T10:00:00.000+0000"
+	issues[3].Fields.Updated = "2024-01-04T10:00:00.000+0000"
+
+	tests := []struct {
+		spec string
+		want []string
+	}{
+		{"key", []string{"IS-1", "IS-2", "IS-9", "IS-10"}},
+		{"points:desc,key:asc", []string{"IS-2", "IS-9", "IS-10", "IS-1"}},
+		{"points:desc,key:desc", []string{"IS-2", "IS-10", "IS-9", "IS-1"}},
+		{"status,key", []string{"IS-2", "IS-9", "IS-1", "IS-10"}},
+		{"updated:desc", []string{"IS-1", "IS-10", "IS-9", "IS-2"}},
+		// Stable: equal issues keep their order.
+		{"type", []string{"IS-10", "IS-2", "IS-9", "IS-1"}},
+	}
+	for _, tt := range tests {
+		keys, err := parseSortSpec(tt.spec)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.spec, err)
+		}
+		list := slices.Clone(issues)
+		sortIssues(list, keys)
+		var got []string
+		for _, ji := range list {
+			got = append(got, ji.Key)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("-sort %s = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestParseSortSpecInvalid(t *testing.T) {
+	for _, spec := range []string{"priority", "points:up", "key:asc,bogus:desc", " , "} {
+		if _, err := parseSortSpec(spec); !errors.Is(err, ErrUsage) {
+			t.Errorf("parseSortSpec(%q): err = %v, want a usage error", spec, err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// parseSprintDate reads a -start/-end value: a date (taken as midnight in
+// loc) or a full RFC 3339 timestamp.
+func parseSprintDate(flagName, s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, s, loc); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, usageError("invalid -%s %q (want YYYY-MM-DD or RFC 3339)", flagName, s)
+}
+
+// sprintStateBody checks that sp may move to state and builds the update.
+// Only future sprints start and only active ones close, as on the board.
+func sprintStateBody(sp Sprint, state string, start, end time.Time) (map[string]any, error) {
+	body := map[string]any{"state": state}
+	switch state {
+	case "active":
+		if sp.State != "future" {
+			return nil, usageError("sprint %d is %s; only future sprints can be started", sp.ID, sp.State)
+		}
+		if !end.After(start) {
+			return nil, usageError("-end must be after -start")
+		}
+		body["startDate"] = start.Format(jiraTimeLayout)
+		body["endDate"] = end.Format(jiraTimeLayout)
+	case "closed":
+		if sp.State != "active" {
+			return nil, usageError("sprint %d is %s; only active sprints can be closed", sp.ID, sp.State)
+		}
+	}
+	return body, nil
+}
+
+func sprintCmd(cfg JiraConfig, args []string) error {
+	const usage = "usage: jira-cli sprint start <id> -start DATE -end DATE | sprint close <id>"
+	fs := newFlagSet("sprint")
+	startFlag := fs.String("start", "", "start date (sprint start only)")
+	endFlag := fs.String("end", "", "end date (sprint start only)")
+	pos := parseArgs(fs, args)
+	if len(pos) != 2 {
+		return usageError(usage)
+	}
+	var state string
+	switch pos[0] {
+	case "start":
+		state = "active"
+	case "close":
+		state = "closed"
+	default:
+		return usageError(usage)
+	}
+	id, err := strconv.Atoi(pos[1])
+	if err != nil || id <= 0 {
+		return usageError("sprint id must be a positive integer, got %q", pos[1])
+	}
+
+	var start, end time.Time
+	if state == "active" {
+		if *startFlag == "" || *endFlag == "" {
+			return usageError("sprint start needs -start and -end")
+		}
+		loc, err := displayLocation(cfg)
+		if err != nil {
+			return err
+		}
+		if start, err = parseSprintDate("start", *startFlag, loc); err != nil {
+			return err
+		}
+		if end, err = parseSprintDate("end", *endFlag, loc); err != nil {
+			return err
+		}
+	} else if *startFlag != "" || *endFlag != "" {
+		return usageError("-start and -end only apply to sprint start")
+	}
+
+	sp, err := getSprint(cfg, id)
+	if err != nil {
+		return err
+	}
+	body, err := sprintStateBody(sp, state, start, end)
+	if err != nil {
+		return err
+	}
+	// POST is Jira's partial update; PUT would clear the goal and every
+	// other field left out of the body.
+	if err := doJSON(cfg, http.MethodPost, apiURL(cfg, nil, "rest/agile/1.0/sprint", strconv.Itoa(id)), body, nil); err != nil {
+		return err
+	}
+	delete(sprintCache, id)
+	if state == "active" {
+		fmt.Printf("Started %s\n", sp.Name)
+	} else {
+		fmt.Printf("Closed %s\n", sp.Name)
+	}
+	return nil
+}
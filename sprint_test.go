@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSprintStateBody(t *testing.T) {
+	start := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 14)
+
+	body, err := sprintStateBody(Sprint{ID: 7, State: "future"}, "active", start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := json.Marshal(body)
+	if want := `{"endDate":"2024-05-20T00:00:00.000+0000","startDate":"2024-05-06T00:00:00.000+0000","state":"active"}`; string(got) != want {
+		t.Errorf("start: got  %s\nwant %s", got, want)
+	}
+
+	body, err = sprintStateBody(Sprint{ID: 7, State: "active"}, "closed", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := json.Marshal(body); string(got) != `{"state":"closed"}` {
+		t.Errorf("close: got %s", got)
+	}
+
+	invalid := []struct {
+		from, to string
+	}{
+		{"active", "active"},
+		{"closed", "active"},
+		{"future", "closed"},
+		{"closed", "closed"},
+	}
+	for _, tt := range invalid {
+		if _, err := sprintStateBody(Sprint{ID: 7, State: tt.from}, tt.to, start, end); !errors.Is(err, ErrUsage) {
+			t.Errorf("%s → %s: err = %v, want a usage error", tt.from, tt.to, err)
+		}
+	}
+	if _, err := sprintStateBody(Sprint{ID: 7, State: "future"}, "active", end, start); !errors.Is(err, ErrUsage) {
+		t.Errorf("end before start: err = %v, want a usage error", err)
+	}
+}
+
+func TestSprintCmdStart(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("GET /rest/agile/1.0/sprint/7", 200, Sprint{ID: 7, Name: "Sprint 7", State: "future"})
+	f.reply("POST /rest/agile/1.0/sprint/7", 200, nil)
+	setGlobal(t, &tzOverride, "UTC")
+	setGlobal(t, &displayZone, nil)
+
+	var err error
+	out := captureStdout(t, func() {
+		err = sprintCmd(f.config(), []string{"start", "7", "-start", "2024-05-06", "-end", "2024-05-20"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	posts := f.requests("POST", "/rest/agile/1.0/sprint/7")
+	want := `{"endDate":"2024-05-20T00:00:00.000+0000","startDate":"2024-05-06T00:00:00.000+0000","state":"active"}`
+	if len(posts) != 1 || posts[0].Body != want {
+		t.Errorf("requests = %+v, want body %s", posts, want)
+	}
+	if out != "Started Sprint 7\n" {
+		t.Errorf("output = %q", out)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// staleMarker prefixes the summary of issues not updated within -stale.
+const staleMarker = "⚠ "
+
+// staleColor is the default theme's row color for stale issues.
+const staleColor = "33"
+
+// parseAge reads a -stale threshold: a number of days or weeks ("14d",
+// "2w") or anything time.ParseDuration accepts ("36h").
+func parseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	var d time.Duration
+	if unit != 0 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil {
+			return 0, usageError("invalid -stale %q (want e.g. 14d, 2w or 36h)", s)
+		}
+		d = time.Duration(n) * unit
+	} else {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, usageError("invalid -stale %q (want e.g. 14d, 2w or 36h)", s)
+		}
+	}
+	if d <= 0 {
+		return 0, usageError("-stale must be positive")
+	}
+	return d, nil
+}
+
+// isStale reports whether ji was last updated before cutoff. A zero
+// cutoff disables the check, and issues without a readable timestamp are
+// never stale.
+func isStale(ji JiraIssue, cutoff time.Time) bool {
+	if cutoff.IsZero() {
+		return false
+	}
+	t, err := parseJiraTime(ji.Fields.Updated)
+	return err == nil && t.Before(cutoff)
+}
+
+// formatStale is the summary printed after the listing, or "" when no
+// issue is stale.
+func formatStale(issues []JiraIssue, cutoff time.Time, age string) string {
+	var keys []string
+	for _, ji := range issues {
+		if isStale(ji, cutoff) {
+			keys = append(keys, ji.Key)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Slice(keys, func(i, j int) bool { return keyLess(keys[i], keys[j]) })
+	return fmt.Sprintf("Stale (not updated in %s): %s", age, strings.Join(keys, ", "))
+}
+
+// rowColor is the color an issue's row is drawn in: its label color, or
+// the stale color.
+func rowColor(ji JiraIssue, opts formatOptions) string {
+	if c := labelColor(ji, opts.LabelColors); c != "" {
+		return c
+	}
+	if opts.Theme == nil {
+		return ""
+	}
+	if isStale(ji, opts.StaleBefore) && opts.Theme.Stale != "" {
+		return opts.Theme.Stale
+	}
+	return opts.Theme.Status[ji.Fields.Status.Category.Key]
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStaleClassification(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	age, err := parseAge("14d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cutoff := now.Add(-age)
+
+	issue := func(key, updated string) JiraIssue {
+		ji := sampleIssue(key, "Open", 1)
+		ji.Fields.Updated = updated
+		return ji
+	}
+	issues := []JiraIssue{
+		issue("IS-10", "2024-06-01T09:00:00.000+0000"),
+		issue("IS-2", "2024-06-16T11:59:00.000+0000"),
+		issue("IS-3", "2024-06-16T12:01:00.000+0000"),
+		issue("IS-4", "2024-06-29T08:00:00.000+0200"),
+		issue("IS-5", ""),
+	}
+	want := map[string]bool{"IS-10": true, "IS-2": true}
+	for _, ji := range issues {
+		if got := isStale(ji, cutoff); got != want[ji.Key] {
+			t.Errorf("isStale(%s) = %v, want %v", ji.Key, got, want[ji.Key])
+		}
+	}
+	if got, want := formatStale(issues, cutoff, "14d"), "Stale (not updated in 14d): IS-2, IS-10"; got != want {
+		t.Errorf("formatStale = %q, want %q", got, want)
+	}
+	if isStale(issues[0], time.Time{}) {
+		t.Error("a zero cutoff marked an issue stale")
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"14d", 14 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"36h", 36 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got, err := parseAge(tt.in); err != nil || got != tt.want {
+			t.Errorf("parseAge(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", "d", "0d", "-1w", "soon"} {
+		if _, err := parseAge(in); !errors.Is(err, ErrUsage) {
+			t.Errorf("parseAge(%q): err = %v, want a usage error", in, err)
+		}
+	}
+}
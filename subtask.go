@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// subtaskFields builds the create payload for a subtask of parent.
+func subtaskFields(parent JiraIssue, issueType, summary string) map[string]any {
+	fields := createFields(createOptions{
+		Project: parent.Fields.Project.Key,
+		Type:    issueType,
+		Summary: summary,
+	})
+	fields["parent"] = map[string]any{"key": parent.Key}
+	return fields
+}
+
+func subtaskCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("subtask")
+	issueType := fs.String("type", "Sub-task", "subtask issue type name")
+	pos := parseArgs(fs, args)
+	if len(pos) < 2 {
+		return usageError("usage: jira-cli subtask <PARENT-KEY> <summary>")
+	}
+	summary := strings.TrimSpace(strings.Join(pos[1:], " "))
+	if summary == "" {
+		return usageError("missing subtask summary")
+	}
+
+	parent, err := getIssue(cfg, expandKey(cfg, pos[0]), "project", "issuetype")
+	if err != nil {
+		return err
+	}
+	if parent.Fields.IssueType.Subtask {
+		return usageError("%s is itself a subtask", parent.Key)
+	}
+
+	key, err := createIssue(cfg, subtaskFields(parent, *issueType, summary))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Created %s under %s\n", key, parent.Key)
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestSubtaskFields(t *testing.T) {
+	parent := JiraIssue{Key: "PROJ-1"}
+	parent.Fields.Project.Key = "PROJ"
+	got, err := json.Marshal(subtaskFields(parent, "Sub-task", "Write docs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"issuetype":{"name":"Sub-task"},"parent":{"key":"PROJ-1"},"project":{"key":"PROJ"},"summary":"Write docs"}`
+	if string(got) != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestSubtaskOfSubtask(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("GET /rest/api/3/issue/PROJ-2", 200, map[string]any{
+		"key":    "PROJ-2",
+		"fields": map[string]any{"project": map[string]any{"key": "PROJ"}, "issuetype": map[string]any{"name": "Sub-task", "subtask": true}},
+	})
+	err := subtaskCmd(f.config(), []string{"PROJ-2", "Nested"})
+	if !errors.Is(err, ErrUsage) {
+		t.Errorf("err = %v, want a usage error", err)
+	}
+	if n := len(f.requests("POST", "/rest/api/3/issue")); n != 0 {
+		t.Errorf("%d create requests, want 0", n)
+	}
+}
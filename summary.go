@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// issueSummary is what the summary command reports about your issues.
+type issueSummary struct {
+	Issues        int           `json:"issues"`
+	Statuses      []statusCount `json:"statuses"`
+	Points        float64       `json:"points"`
+	ActiveSprints []string      `json:"active_sprints"`
+}
+
+type statusCount struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"`
+}
+
+// summarize counts issues by status, largest first as -count-by does,
+// and sums their points.
+func summarize(issues []JiraIssue) issueSummary {
+	s := issueSummary{Issues: len(issues), Statuses: []statusCount{}, ActiveSprints: []string{}}
+	for _, c := range countBy(issues, groupFields["status"]) {
+		s.Statuses = append(s.Statuses, statusCount{c.Value, c.Count})
+	}
+	for _, ji := range issues {
+		s.Points += ji.Fields.Points
+	}
+	for _, sp := range activeSprints(issues) {
+		s.ActiveSprints = append(s.ActiveSprints, sp.Name)
+	}
+	return s
+}
+
+// String is the one-line form, e.g.
+//
+//	5 issues: 2 In Progress, 1 In Review, 2 Open, 8 pts, active sprint "Sprint 12"
+func (s issueSummary) String() string {
+	parts := make([]string, 0, len(s.Statuses)+2)
+	for _, c := range s.Statuses {
+		parts = append(parts, fmt.Sprintf("%d %s", c.Count, c.Status))
+	}
+	pts := "0"
+	if s.Points != 0 {
+		pts = formatPoints(s.Points)
+	}
+	parts = append(parts, pts+" pts")
+	quoted := make([]string, len(s.ActiveSprints))
+	for i, name := range s.ActiveSprints {
+		quoted[i] = strconv.Quote(name)
+	}
+	switch len(quoted) {
+	case 0:
+		parts = append(parts, "no active sprint")
+	case 1:
+		parts = append(parts, "active sprint "+quoted[0])
+	default:
+		parts = append(parts, "active sprints "+strings.Join(quoted, ", "))
+	}
+	return fmt.Sprintf("%d %s: %s", s.Issues, plural(s.Issues, "issue"), strings.Join(parts, ", "))
+}
+
+func summaryCmd(cfg JiraConfig, args []string) error {
+	fs := newFlagSet("summary")
+	asJSON := fs.Bool("json", false, "print the summary as JSON")
+	if pos := parseArgs(fs, args); len(pos) > 0 {
+		return usageError("usage: jira-cli summary [-json]")
+	}
+
+	issues, err := getIssues(cfg)
+	if err != nil {
+		return err
+	}
+	s := summarize(issues)
+	if *asJSON {
+		buf, err := json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(buf))
+		return nil
+	}
+	fmt.Println(s)
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func sampleSummaryIssues() []JiraIssue {
+	sprint := []Sprint{{ID: 12, Name: "Sprint 12", State: "active"}}
+	issues := []JiraIssue{
+		sampleIssue("IS-1", "Open", 3),
+		sampleIssue("IS-2", "In Progress", 2),
+		sampleIssue("IS-3", "Open", 0),
+		sampleIssue("IS-4", "Done", 1.5),
+		sampleIssue("IS-5", "In Progress", 1),
+		sampleIssue("IS-6", "Open", 0.5),
+	}
+	for i := range issues[:4] {
+		issues[i].Fields.Sprints = sprint
+	}
+	return issues
+}
+
+func TestSummaryLine(t *testing.T) {
+	got := summarize(sampleSummaryIssues()).String()
+	want := `6 issues: 3 Open, 2 In Progress, 1 Done, 8 pts, active sprint "Sprint 12"`
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	one := summarize([]JiraIssue{sampleIssue("IS-1", "Open", 0)}).String()
+	if want := "1 issue: 1 Open, 0 pts, no active sprint"; one != want {
+		t.Errorf("got  %s\nwant %s", one, want)
+	}
+	if want := "0 issues: 0 pts, no active sprint"; summarize(nil).String() != want {
+		t.Errorf("empty summary = %q, want %q", summarize(nil).String(), want)
+	}
+}
+
+func TestSummaryCmdJSON(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("POST /rest/api/3/search/jql", 200, searchResult(sampleSummaryIssues()...))
+
+	var err error
+	out := captureStdout(t, func() { err = summaryCmd(f.config(), []string{"-json"}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got issueSummary
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("%v in %s", err, out)
+	}
+	if got.Issues != 6 || got.Points != 8 || len(got.Statuses) != 3 || got.Statuses[0] != (statusCount{"Open", 3}) || len(got.ActiveSprints) != 1 {
+		t.Errorf("summary = %+v", got)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"text/template"
+)
+
+// templateIssue is the data passed to -template for each issue.
+type templateIssue struct {
+	Key     string
+	Summary string
+	Status  string
+	Type    string
+	Points  float64
+	Sprint  string
+}
+
+var templateFuncs = template.FuncMap{
+	"points": formatPoints,
+	"upper":  strings.ToUpper,
+	"lower":  strings.ToLower,
+}
+
+func parseIssueTemplate(text string) (*template.Template, error) {
+	t, err := template.New("issue").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, usageError("invalid template: %v", err)
+	}
+	return t, nil
+}
+
+func newTemplateIssue(ji JiraIssue) templateIssue {
+	f := ji.Fields
+	return templateIssue{
+		Key:     ji.Key,
+		Summary: f.Summary,
+		Status:  f.Status.Name,
+		Type:    f.IssueType.Name,
+		Points:  f.Points,
+		Sprint:  sprintName(f.Sprints),
+	}
+}
+
+// renderTemplate executes t once per issue, one line each.
+func renderTemplate(t *template.Template, issues []JiraIssue) (string, error) {
+	var b strings.Builder
+	for _, ji := range issues {
+		if err := t.Execute(&b, newTemplateIssue(ji)); err != nil {
+			return "", err
+		}
+		b.WriteByte('\n')
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
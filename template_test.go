@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sampleIssue(key, status string, points float64) JiraIssue {
+	ji := JiraIssue{Key: key}
+	ji.Fields.Summary = "Summary of " + key
+	ji.Fields.Status.Name = status
+	ji.Fields.IssueType.Name = "Story"
+	ji.Fields.Points = points
+	return ji
+}
+
+func TestRenderTemplate(t *testing.T) {
+	tmpl, err := parseIssueTemplate("{{.Key}} {{points .Points}} {{.Status | upper}} {{.Summary}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := renderTemplate(tmpl, []JiraIssue{sampleIssue("IS-1", "In Progress", 2.5), sampleIssue("IS-2", "Open", 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "IS-1 2.5 IN PROGRESS Summary of IS-1\nIS-2 - OPEN Summary of IS-2"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestParseIssueTemplateError(t *testing.T) {
+	_, err := parseIssueTemplate("{{.Key")
+	if !errors.Is(err, ErrUsage) || !strings.Contains(err.Error(), "invalid template") {
+		t.Errorf("err = %v, want an invalid template usage error", err)
+	}
+}
+
+func TestTemplateFile(t *testing.T) {
+	f := newFakeJira(t)
+	f.reply("POST /rest/api/3/search/jql", 200, searchResult(sampleIssue("IS-1", "Open", 2), sampleIssue("IS-2", "Done", 1)))
+	path := filepath.Join(t.TempDir(), "issue.tmpl")
+	if err := os.WriteFile(path, []byte("{{.Key}}: {{.Status}}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var err error
+	out := captureStdout(t, func() { err = listFlow(f.config(), listOptions{TemplateFile: path}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "IS-1: Open\nIS-2: Done\n"; out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+
+	err = listFlow(f.config(), listOptions{Template: "{{.Key}}", TemplateFile: path})
+	if !errors.Is(err, ErrUsage) || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Errorf("-template with -template-file: err = %v", err)
+	}
+	if err := listFlow(f.config(), listOptions{TemplateFile: filepath.Join(t.TempDir(), "missing")}); !errors.Is(err, ErrUsage) {
+		t.Errorf("missing -template-file: err = %v, want a usage error", err)
+	}
+}
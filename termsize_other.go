@@ -0,0 +1,9 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package main
+
+import "os"
+
+func ttyColumns(f *os.File) int { return 0 }
+
+func isTTY(f *os.File) bool { return isTerminal(f) }
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// theme is the listing's palette: row colors by status category key,
+// for stale issues, and for labels on top of [label_colors]. A mono
+// theme turns color off altogether.
+type theme struct {
+	Status map[string]string
+	Stale  string
+	Labels map[string]string
+	Mono   bool
+}
+
+// themes are the built-in palettes. default keeps rows uncolored except
+// for stale ones; dark and light also set the done and in-progress
+// categories apart in colors that stay readable on that background.
+var themes = map[string]theme{
+	"default": {Stale: staleColor},
+	"dark": {
+		Status: map[string]string{"indeterminate": "36", "done": "90"},
+		Stale:  staleColor,
+	},
+	"light": {
+		Status: map[string]string{"indeterminate": "34", "done": "90"},
+		Stale:  "35",
+	},
+	"mono": {Mono: true},
+}
+
+// loadTheme resolves a -theme or JIRA_THEME name: a built-in, or a
+// [theme.NAME] config section, which takes a color for each status
+// category, stale rows and labels:
+//
+//	[theme.solarized]
+//	inprogress = cyan
+//	done = gray
+//	stale = magenta
+//	label.urgent = red
+//
+// Colors are those of [label_colors], or none; a section named after a
+// built-in theme replaces it.
+func loadTheme(c fileConfig, name string) (theme, error) {
+	if name == "" {
+		name = "default"
+	}
+	name = strings.ToLower(name)
+	sec := c.Section("theme." + name)
+	if sec == nil {
+		if th, ok := themes[name]; ok {
+			return th, nil
+		}
+		return theme{}, usageError("unknown theme %q (want %s, or a [theme.%s] config section)", name, strings.Join(themeNames(), ", "), name)
+	}
+
+	th := theme{Status: map[string]string{}, Labels: map[string]string{}}
+	for k, v := range sec {
+		code := ""
+		if !strings.EqualFold(v, "none") {
+			var ok bool
+			if code, ok = ansiColors[strings.ToLower(v)]; !ok {
+				return theme{}, fmt.Errorf("theme.%s: unknown color %q for %q", name, v, k)
+			}
+		}
+		k = strings.ToLower(k)
+		if label, ok := strings.CutPrefix(k, "label."); ok {
+			th.Labels[label] = code
+			continue
+		}
+		if k == "stale" {
+			th.Stale = code
+			continue
+		}
+		cat, err := parseCategory(k)
+		if err != nil {
+			return theme{}, fmt.Errorf("theme.%s: unknown key %q (want todo, inprogress, done, stale or label.NAME)", name, k)
+		}
+		th.Status[cat] = code
+	}
+	return th, nil
+}
+
+func themeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// themeName is -theme; activeTheme is what it, or JIRA_THEME, resolves to.
+var themeName string
+
+var activeTheme = themes["default"]
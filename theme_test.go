@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func categoryIssue(key, category string) JiraIssue {
+	ji := sampleIssue(key, "Status of "+key, 0)
+	ji.Fields.Status.Category.Key = category
+	return ji
+}
+
+func TestThemeColors(t *testing.T) {
+	tests := []struct {
+		theme                  string
+		todo, inProgress, done string
+	}{
+		{"default", "", "", ""},
+		{"dark", "", "36", "90"},
+		{"LIGHT", "", "34", "90"},
+		{"mono", "", "", ""},
+	}
+	for _, tt := range tests {
+		th, err := loadTheme(nil, tt.theme)
+		if err != nil {
+			t.Fatalf("loadTheme(%q): %v", tt.theme, err)
+		}
+		opts := formatOptions{Theme: &th}
+		for cat, want := range map[string]string{"new": tt.todo, "indeterminate": tt.inProgress, "done": tt.done} {
+			if got := rowColor(categoryIssue("IS-1", cat), opts); got != want {
+				t.Errorf("%s theme, %s issue: color %q, want %q", tt.theme, cat, got, want)
+			}
+		}
+	}
+}
+
+func TestCustomTheme(t *testing.T) {
+	c, err := parseConfig(strings.NewReader("[theme.solarized]\ninprogress = cyan\ndone = none\nstale = magenta\nlabel.urgent = red\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	th, err := loadTheme(c, "solarized")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if th.Status["indeterminate"] != "36" || th.Status["done"] != "" || th.Stale != "35" || th.Labels["urgent"] != "31" {
+		t.Errorf("theme = %+v", th)
+	}
+
+	bad, _ := parseConfig(strings.NewReader("[theme.bad]\ndone = chartreuse\n"))
+	if _, err := loadTheme(bad, "bad"); err == nil || !strings.Contains(err.Error(), `unknown color "chartreuse"`) {
+		t.Errorf("unknown color: err = %v", err)
+	}
+	if _, err := loadTheme(nil, "neon"); !errors.Is(err, ErrUsage) {
+		t.Errorf("unknown theme: err = %v, want a usage error", err)
+	}
+}
+
+func TestMonoThemeDisablesColor(t *testing.T) {
+	// /dev/null is a character device, so it passes for a terminal.
+	tty, err := os.Open(os.DevNull)
+	if err != nil || !isTerminal(tty) {
+		t.Skip("no character device to stand in for a terminal")
+	}
+	defer tty.Close()
+	t.Setenv("NO_COLOR", "")
+
+	setGlobal(t, &activeTheme, themes["dark"])
+	if !colorEnabled(tty) {
+		t.Fatal("color disabled under the dark theme")
+	}
+	activeTheme = themes["mono"]
+	if colorEnabled(tty) {
+		t.Error("color enabled under the mono theme")
+	}
+}
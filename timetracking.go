@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TimeTracking is the timetracking field. The strings are Jira's own
+// rendering, such as "1d 2h"; the seconds are there for summing.
+type TimeTracking struct {
+	OriginalEstimate         string `json:"originalEstimate"`
+	RemainingEstimate        string `json:"remainingEstimate"`
+	TimeSpent                string `json:"timeSpent"`
+	RemainingEstimateSeconds int    `json:"remainingEstimateSeconds"`
+}
+
+// timeColumns replace points in the default columns for -time.
+var timeColumns = []string{"key", "estimate", "remaining", "spent", "status", "type", "summary"}
+
+// formatDuration renders seconds in hours and minutes, e.g. "26h 30m".
+// Days and weeks are left out because their length in hours is a
+// per-instance setting.
+func formatDuration(seconds int) string {
+	if seconds <= 0 {
+		return "-"
+	}
+	h, m := seconds/3600, seconds%3600/60
+	var parts []string
+	if h > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", h))
+	}
+	if m > 0 || h == 0 {
+		parts = append(parts, fmt.Sprintf("%dm", m))
+	}
+	return strings.Join(parts, " ")
+}
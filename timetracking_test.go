@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestTimeColumnsAndRemainingSum(t *testing.T) {
+	a, b, c := sampleIssue("IS-1", "Open", 0), sampleIssue("IS-2", "Open", 0), sampleIssue("IS-3", "Open", 0)
+	a.Fields.Time = TimeTracking{OriginalEstimate: "1d", RemainingEstimate: "4h", TimeSpent: "4h", RemainingEstimateSeconds: 4 * 3600}
+	b.Fields.Time = TimeTracking{OriginalEstimate: "2h", RemainingEstimate: "1h 30m", RemainingEstimateSeconds: 5400}
+	for _, ji := range []*JiraIssue{&a, &b} {
+		ji.Fields.Sprints = []Sprint{{ID: 1, Name: "S1", State: "active"}}
+	}
+
+	out := formatIssuesBySprint([]JiraIssue{a, b, c}, formatOptions{Time: true, Columns: timeColumns})
+	want := "Sprint: S1 (2 issues, 5h 30m remaining)\n" +
+		"  IS-1\t1d\t4h\t4h\tOpen\tStory\tSummary of IS-1\n" +
+		"  IS-2\t2h\t1h 30m\t-\tOpen\tStory\tSummary of IS-2\n" +
+		"\n" +
+		"Sprint: Backlog (1 issues, - remaining)\n" +
+		"  IS-3\t-\t-\t-\tOpen\tStory\tSummary of IS-3\n"
+	if out != want {
+		t.Errorf("got:\n%q\nwant:\n%q", out, want)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    string
+	}{
+		{0, "-"},
+		{60, "1m"},
+		{3600, "1h"},
+		{95400, "26h 30m"},
+	}
+	for _, tt := range tests {
+		if got := formatDuration(tt.seconds); got != tt.want {
+			t.Errorf("formatDuration(%d) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultWrapWidth is used for -wrap when the terminal size is unknown.
+const defaultWrapWidth = 100
+
+// terminalWidth is $COLUMNS, else the size of the terminal behind f,
+// else defaultWrapWidth.
+func terminalWidth(f *os.File) int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	if isTerminal(f) {
+		if n := ttyColumns(f); n > 0 {
+			return n
+		}
+	}
+	return defaultWrapWidth
+}
+
+// minWrapWidth keeps summaries readable when the other columns leave
+// almost no room.
+const minWrapWidth = 20
+
+// wrapText breaks s into lines of at most width runes at spaces, cutting
+// words that are longer than a whole line.
+func wrapText(s string, width int) []string {
+	var lines []string
+	line := ""
+	for _, w := range strings.Fields(s) {
+		for utf8.RuneCountInString(w) > width {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			r := []rune(w)
+			lines = append(lines, string(r[:width]))
+			w = string(r[width:])
+		}
+		switch {
+		case line == "":
+			line = w
+		case utf8.RuneCountInString(line)+1+utf8.RuneCountInString(w) <= width:
+			line += " " + w
+		default:
+			lines = append(lines, line)
+			line = w
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// summaryColumn is the index of the summary cell in a row, or -1.
+func summaryColumn(opts formatOptions) int {
+	names := opts.Columns
+	if len(names) == 0 {
+		names = defaultColumns
+	}
+	for i, n := range names {
+		if n == "summary" {
+			return i
+		}
+	}
+	return -1
+}
+
+// cellOffset is the screen column where cell i starts, for a row printed
+// after indent spaces with padded widths (or tab stops when widths is nil).
+func cellOffset(cells []string, i int, widths []int, indent int) int {
+	pos := indent
+	for j := 0; j < i; j++ {
+		if widths != nil {
+			pos += widths[j] + 2
+		} else {
+			pos = (pos+visibleWidth(cells[j]))/8*8 + 8
+		}
+	}
+	return pos
+}
+
+// wrapSummary cuts the summary cell of cells down to its first line for
+// -wrap and returns the remaining lines, each already indented to the
+// summary column so key, points and status stay on the first line only.
+func wrapSummary(cells []string, widths []int, indent int, opts formatOptions) []string {
+	si := summaryColumn(opts)
+	if opts.Wrap <= 0 || si < 0 || si >= len(cells) {
+		return nil
+	}
+	offset := cellOffset(cells, si, widths, indent)
+	lines := wrapText(cells[si], max(minWrapWidth, opts.Wrap-offset))
+	cells[si] = lines[0]
+	pad := strings.Repeat(" ", offset)
+	for i := 1; i < len(lines); i++ {
+		lines[i] = pad + lines[i]
+	}
+	return lines[1:]
+}
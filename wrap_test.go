@@ -0,0 +1,45 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWrapText(t *testing.T) {
+	got := wrapText("Fix the login page so that it no longer times out on slow networks", 20)
+	want := []string{"Fix the login page", "so that it no longer", "times out on slow", "networks"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := wrapText("short", 20); !slices.Equal(got, []string{"short"}) {
+		t.Errorf("short text: %q", got)
+	}
+	if got := wrapText("abcdefghij", 4); !slices.Equal(got, []string{"abcd", "efgh", "ij"}) {
+		t.Errorf("long word: %q", got)
+	}
+}
+
+func TestWrapListing(t *testing.T) {
+	ji := sampleIssue("IS-1", "Open", 3)
+	ji.Fields.Summary = strings.Repeat("word ", 20)
+	opts := formatOptions{Padded: true, Wrap: 40, Columns: []string{"key", "points", "summary"}}
+
+	lines := strings.Split(strings.TrimSuffix(formatFlat([]JiraIssue{ji}, opts), "\n"), "\n")
+	// Key and points take 14 columns, leaving 26 for five words a line.
+	if len(lines) != 1+4 {
+		t.Fatalf("%d lines, want a header and 4 summary lines:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	offset := strings.Index(lines[1], "word")
+	for i, l := range lines[1:] {
+		if n := len(l); n > 40 {
+			t.Errorf("line %d is %d wide, over 40: %q", i+1, n, l)
+		}
+		if i > 0 && (strings.TrimLeft(l, " ") == l || strings.Index(l, "word") != offset) {
+			t.Errorf("continuation line %d not indented to the summary column: %q", i+1, l)
+		}
+	}
+	if !strings.HasPrefix(lines[1], "IS-1") || strings.Contains(strings.Join(lines[2:], ""), "IS-1") {
+		t.Errorf("key should be on the first line only:\n%s", strings.Join(lines, "\n"))
+	}
+}